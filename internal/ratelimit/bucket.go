@@ -2,106 +2,122 @@ package ratelimit
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"time"
 )
 
 // TokenBucket implements a token bucket rate limiter
 type TokenBucket struct {
-	capacity   int           // Maximum tokens (burst capacity)
-	tokens     int           // Current available tokens
-	refillRate time.Duration // Time between token refills
-	lastRefill time.Time     // Last time tokens were refilled
-	mutex      sync.Mutex    // Thread safety
+	policy    *bucketPolicy // Token count and refill arithmetic
+	scheduler scheduler     // Queue of waiters, served by priority
+	mutex     sync.Mutex    // Thread safety
 }
 
 // NewTokenBucket creates a new token bucket rate limiter
 // SpaceTraders API: 2 requests per second, 30 burst capacity, 60 second window
 func NewTokenBucket() *TokenBucket {
-	now := time.Now()
-	return &TokenBucket{
-		capacity:   30,                     // 30 request burst limit
-		tokens:     30,                     // Start with full bucket
-		refillRate: 500 * time.Millisecond, // 2 per second = 500ms per token
-		lastRefill: now,
-		mutex:      sync.Mutex{},
-	}
+	return NewCustomTokenBucket(
+		30,                   // 30 request burst limit
+		500*time.Millisecond, // 2 per second = 500ms per token
+	)
 }
 
 // NewCustomTokenBucket creates a token bucket with custom parameters
 func NewCustomTokenBucket(capacity int, refillRate time.Duration) *TokenBucket {
-	now := time.Now()
-	return &TokenBucket{
-		capacity:   capacity,
-		tokens:     capacity, // Start full
-		refillRate: refillRate,
-		lastRefill: now,
-		mutex:      sync.Mutex{},
+	clock := SystemClock()
+	tb := &TokenBucket{
+		policy: newBucketPolicy(capacity, refillRate, clock.Now()),
 	}
+	tb.scheduler = newScheduler(&tb.mutex, clock, tb.policy)
+	return tb
 }
 
-// Wait blocks until a token is available or context is cancelled
+// Wait blocks until a token is available or context is cancelled. Waiters
+// are served in order of the priority attached with WithPriority, and in
+// arrival order within a priority.
 func (tb *TokenBucket) Wait(ctx context.Context) error {
-	for {
-		if tb.Allow() {
-			return nil
-		}
+	return tb.scheduler.wait(ctx)
+}
 
-		// Calculate wait time until next token
-		tb.mutex.Lock()
-		waitTime := tb.refillRate - time.Since(tb.lastRefill)
-		tb.mutex.Unlock()
+// Allow checks if a token is available and consumes it if so. It never
+// takes a token ahead of requests blocked in Wait.
+func (tb *TokenBucket) Allow() bool {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
 
-		if waitTime <= 0 {
-			waitTime = tb.refillRate // Minimum wait
-		}
+	return tb.scheduler.tryTake()
+}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(waitTime):
-			// Continue to try again
-		}
-	}
+// TryAllow attempts to consume a token without blocking
+func (tb *TokenBucket) TryAllow() bool {
+	return tb.Allow()
 }
 
-// Allow checks if a token is available and consumes it if so
-func (tb *TokenBucket) Allow() bool {
+// clockSource returns the clock the bucket refills by
+func (tb *TokenBucket) clockSource() Clock {
+	return tb.scheduler.clock
+}
+
+// SetPriorityAging sets how long a waiter waits before it is promoted one
+// priority level; zero disables promotion
+func (tb *TokenBucket) SetPriorityAging(d time.Duration) {
 	tb.mutex.Lock()
 	defer tb.mutex.Unlock()
 
-	tb.refill()
+	tb.scheduler.aging = d
+}
 
-	if tb.tokens > 0 {
-		tb.tokens--
-		return true
-	}
+// Reset resets the token bucket to full capacity
+func (tb *TokenBucket) Reset() {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
 
-	return false
+	now := tb.scheduler.clock.Now()
+	tb.policy.reset(now)
+	tb.scheduler.dispatch(now)
 }
 
-// TryAllow attempts to consume a token without blocking
-func (tb *TokenBucket) TryAllow() bool {
+// Reserve takes n tokens now, borrowing against future refills if needed,
+// and returns a reservation reporting when they are all available. Requests
+// queued in Wait are served after the reservation has been paid back.
+func (tb *TokenBucket) Reserve(n int) *Reservation {
 	tb.mutex.Lock()
 	defer tb.mutex.Unlock()
 
-	tb.refill()
-
-	if tb.tokens > 0 {
-		tb.tokens--
-		return true
+	clock := tb.scheduler.clock
+	readyAt, refund, ok := tb.policy.reserve(clock.Now(), n)
+	if !ok {
+		return rejectedReservation(n, clock)
 	}
 
-	return false
+	return newReservation(n, readyAt, clock, func(unused int) {
+		tb.mutex.Lock()
+		defer tb.mutex.Unlock()
+
+		now := clock.Now()
+		refund(now, unused)
+		tb.scheduler.dispatch(now)
+	})
 }
 
-// Reset resets the token bucket to full capacity
-func (tb *TokenBucket) Reset() {
+// SetRemaining corrects the token count from the number of requests the
+// server reports as remaining. The count is only ever lowered, since
+// requests still in flight have not yet been seen by the server.
+func (tb *TokenBucket) SetRemaining(remaining int) {
 	tb.mutex.Lock()
 	defer tb.mutex.Unlock()
 
-	tb.tokens = tb.capacity
-	tb.lastRefill = time.Now()
+	tb.policy.setRemaining(tb.scheduler.clock.Now(), remaining)
+}
+
+// PauseUntil stops the bucket from handing out tokens before t, e.g. after
+// the server rejected a request with 429 Too Many Requests
+func (tb *TokenBucket) PauseUntil(t time.Time) {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+
+	tb.policy.pauseUntil(t)
 }
 
 // GetState returns current bucket state for monitoring
@@ -109,49 +125,192 @@ func (tb *TokenBucket) GetState() BucketState {
 	tb.mutex.Lock()
 	defer tb.mutex.Unlock()
 
-	tb.refill()
+	state := tb.policy.state(tb.scheduler.clock.Now())
+	state.Waiting = len(tb.scheduler.waiters)
+	return state
+}
 
-	return BucketState{
-		Tokens:     tb.tokens,
-		Capacity:   tb.capacity,
-		LastRefill: tb.lastRefill,
-		RefillRate: tb.refillRate,
+// bucketPolicy is the arithmetic of a single bucket of capacity tokens,
+// refilled one token per refillRate. Partial refills carry over, and the
+// token count may go negative while reservations are outstanding.
+type bucketPolicy struct {
+	capacity    int           // Maximum tokens (burst capacity)
+	refillRate  time.Duration // Time between token refills
+	tokens      int           // Current available tokens
+	lastRefill  time.Time     // Time the last whole token was refilled
+	pausedUntil time.Time     // No tokens are handed out before this time
+}
+
+// bucketJSON is the stored form of a bucketPolicy
+type bucketJSON struct {
+	Tokens      int       `json:"tokens"`
+	LastRefill  time.Time `json:"last_refill"`
+	PausedUntil time.Time `json:"paused_until,omitempty"`
+}
+
+// newBucketPolicy creates a full bucket
+func newBucketPolicy(capacity int, refillRate time.Duration, now time.Time) *bucketPolicy {
+	b := &bucketPolicy{capacity: capacity, refillRate: refillRate}
+	b.reset(now)
+	return b
+}
+
+// reset refills the bucket and clears any pause
+func (b *bucketPolicy) reset(now time.Time) {
+	b.tokens = b.capacity
+	b.lastRefill = now
+	b.pausedUntil = time.Time{}
+}
+
+// refill adds the whole tokens earned since the last refill
+func (b *bucketPolicy) refill(now time.Time) {
+	// Add whole tokens, carrying the remainder of the elapsed time over to
+	// the next refill rather than discarding it
+	if b.refillRate > 0 {
+		if added := int(now.Sub(b.lastRefill) / b.refillRate); added > 0 {
+			b.tokens += added
+			b.lastRefill = b.lastRefill.Add(time.Duration(added) * b.refillRate)
+		}
+	}
+	if b.tokens >= b.capacity {
+		b.tokens = b.capacity
+		b.lastRefill = now
+	}
+}
+
+// take consumes a token if available
+func (b *bucketPolicy) take(now time.Time) bool {
+	b.refill(now)
+
+	if b.tokens > 0 && !now.Before(b.pausedUntil) {
+		b.tokens--
+		return true
+	}
+
+	return false
+}
+
+// availableIn returns how long until a token may be taken
+func (b *bucketPolicy) availableIn(now time.Time) time.Duration {
+	var waitTime time.Duration
+	if b.tokens <= 0 {
+		// Reservations may have left the bucket in debt
+		waitTime = b.refillRate*time.Duration(1-b.tokens) - now.Sub(b.lastRefill)
+	}
+	if pause := b.pausedUntil.Sub(now); pause > waitTime {
+		waitTime = pause
 	}
+
+	return waitTime
 }
 
-// refill adds tokens based on elapsed time (must be called with mutex held)
-func (tb *TokenBucket) refill() {
-	now := time.Now()
-	elapsed := now.Sub(tb.lastRefill)
+// reserve takes n tokens, going into debt if needed
+func (b *bucketPolicy) reserve(now time.Time, n int) (time.Time, func(time.Time, int), bool) {
+	if n < 1 || n > b.capacity {
+		return time.Time{}, nil, false
+	}
+
+	b.refill(now)
+	b.tokens -= n
 
-	// Calculate how many tokens to add based on elapsed time
-	tokensToAdd := int(elapsed / tb.refillRate)
+	readyAt := now
+	if b.tokens < 0 {
+		readyAt = b.lastRefill.Add(b.refillRate * time.Duration(-b.tokens))
+	}
+	if b.pausedUntil.After(readyAt) {
+		readyAt = b.pausedUntil
+	}
 
-	if tokensToAdd > 0 {
-		tb.tokens += tokensToAdd
-		if tb.tokens > tb.capacity {
-			tb.tokens = tb.capacity
+	return readyAt, func(now time.Time, unused int) {
+		b.refill(now)
+		b.tokens += unused
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
 		}
-		tb.lastRefill = now
+	}, true
+}
+
+// setRemaining lowers the token count to remaining
+func (b *bucketPolicy) setRemaining(now time.Time, remaining int) {
+	b.refill(now)
+
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining < b.tokens {
+		b.tokens = remaining
 	}
 }
 
+// pauseUntil stops tokens from being handed out before t
+func (b *bucketPolicy) pauseUntil(t time.Time) {
+	if t.After(b.pausedUntil) {
+		b.pausedUntil = t
+	}
+}
+
+// state returns the bucket as a BucketState
+func (b *bucketPolicy) state(now time.Time) BucketState {
+	b.refill(now)
+
+	state := BucketState{
+		Tokens:     b.tokens,
+		Capacity:   b.capacity,
+		LastRefill: b.lastRefill,
+		RefillRate: b.refillRate,
+	}
+	if now.Before(b.pausedUntil) {
+		state.PausedUntil = b.pausedUntil
+	}
+
+	return state
+}
+
+// MarshalJSON stores the bucket's tokens, refill time and pause
+func (b *bucketPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bucketJSON{
+		Tokens:      b.tokens,
+		LastRefill:  b.lastRefill,
+		PausedUntil: b.pausedUntil,
+	})
+}
+
+// UnmarshalJSON loads the state stored by MarshalJSON
+func (b *bucketPolicy) UnmarshalJSON(data []byte) error {
+	var stored bucketJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	b.tokens = stored.Tokens
+	b.lastRefill = stored.LastRefill
+	b.pausedUntil = stored.PausedUntil
+	return nil
+}
+
 // BucketState represents the current state of a token bucket
 type BucketState struct {
-	Tokens     int           `json:"tokens"`
-	Capacity   int           `json:"capacity"`
-	LastRefill time.Time     `json:"last_refill"`
-	RefillRate time.Duration `json:"refill_rate"`
+	Tokens      int           `json:"tokens"`
+	Capacity    int           `json:"capacity"`
+	LastRefill  time.Time     `json:"last_refill"`
+	RefillRate  time.Duration `json:"refill_rate"`
+	PausedUntil time.Time     `json:"paused_until,omitempty"`
+	Waiting     int           `json:"waiting"`
 }
 
 // AvailableIn returns the duration until the next token will be available
 func (bs BucketState) AvailableIn() time.Duration {
-	if bs.Tokens > 0 {
-		return 0
+	var waitTime time.Duration
+	if bs.Tokens <= 0 {
+		// A negative token count is owed to reservations
+		nextRefill := bs.LastRefill.Add(bs.RefillRate * time.Duration(1-bs.Tokens))
+		waitTime = time.Until(nextRefill)
 	}
 
-	nextRefill := bs.LastRefill.Add(bs.RefillRate)
-	waitTime := time.Until(nextRefill)
+	// A pause imposed by the server outlasts any local refill
+	if pause := time.Until(bs.PausedUntil); pause > waitTime {
+		waitTime = pause
+	}
 
 	if waitTime < 0 {
 		return 0
@@ -172,7 +331,7 @@ func (bs BucketState) IsFull() bool {
 
 // Utilization returns the current utilization as a percentage (0.0 to 1.0)
 func (bs BucketState) Utilization() float64 {
-	if bs.Capacity == 0 {
+	if bs.Capacity == 0 || bs.Tokens < 0 {
 		return 0.0
 	}
 	return float64(bs.Tokens) / float64(bs.Capacity)
@@ -0,0 +1,28 @@
+package ratelimit
+
+import "time"
+
+// Clock provides the current time and timers to a limiter, so tests can
+// drive it deterministically instead of sleeping
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock returns a Clock backed by the time package
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// systemClock implements Clock using wall-clock time
+type systemClock struct{}
+
+// Now returns the current time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
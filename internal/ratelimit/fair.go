@@ -0,0 +1,380 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type keyKey struct{}
+
+// WithKey returns a context whose requests are queued under key by a
+// FairQueue, typically the symbol of the ship making the request
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyKey{}, key)
+}
+
+// KeyFromContext returns the key attached to ctx, or "" if there is none
+func KeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(keyKey{}).(string)
+	return key
+}
+
+// KeyStats describes how long the requests of one key waited for the limiter
+type KeyStats struct {
+	Requests  int64         `json:"requests"`   // Requests granted
+	TotalWait time.Duration `json:"total_wait"` // Time granted requests spent queued
+	MaxWait   time.Duration `json:"max_wait"`   // Longest time a request spent queued
+	Waiting   int           `json:"waiting"`    // Requests currently queued
+	Weight    int           `json:"weight"`     // Consecutive turns per round
+}
+
+// AverageWait returns the mean time a granted request spent queued
+func (ks KeyStats) AverageWait() time.Duration {
+	if ks.Requests == 0 {
+		return 0
+	}
+	return ks.TotalWait / time.Duration(ks.Requests)
+}
+
+// FairQueue shares a Limiter between keys, such as one per ship, so a
+// single busy key cannot consume the whole budget. Keys with queued
+// requests take turns in weighted round-robin order: a key of weight n is
+// granted up to n tokens per round.
+//
+// Priorities attached with WithPriority still apply: the highest priority
+// request queued under any key is served first, and only keys holding a
+// request of that priority take turns. Within a key, requests are served by
+// priority and then in arrival order. Waiting requests are promoted as in
+// the limiters, so low priority keys still progress.
+type FairQueue struct {
+	limiter Limiter
+	clock   Clock // The limiter's clock, if it exposes one
+	queues  map[string]*keyQueue
+	active  []string      // Keys with queued requests, in round-robin order
+	turn    int           // Index in active of the key being served
+	served  int           // Tokens granted to that key in the current round
+	aging   time.Duration // Wait after which a request gains one priority level
+	running bool          // Whether the dispatch goroutine is running
+	held    []time.Time   // When tokens whose waiters all gave up were taken
+	mutex   sync.Mutex
+}
+
+// keyQueue holds the waiters and statistics of one key
+type keyQueue struct {
+	waiters []*fairWaiter
+	active  bool // Whether the key is in the round-robin ring
+	weight  int
+	stats   KeyStats
+}
+
+// fairWaiter is a request queued under a key
+type fairWaiter struct {
+	priority Priority
+	enqueued time.Time
+	granted  time.Time     // When the token was granted
+	ready    chan struct{} // Closed once the token is granted
+}
+
+// NewFairQueue creates a fair queue drawing tokens from limiter, keeping
+// time with the limiter's clock if it is one of this package's limiters
+func NewFairQueue(limiter Limiter) *FairQueue {
+	return &FairQueue{
+		limiter: limiter,
+		clock:   clockOf(limiter),
+		queues:  make(map[string]*keyQueue),
+		aging:   DefaultPriorityAging,
+	}
+}
+
+// Wait blocks until the request is granted a token in its key's turn or
+// context is cancelled. The key and priority are taken from the context,
+// see WithKey and WithPriority.
+func (q *FairQueue) Wait(ctx context.Context) error {
+	key := KeyFromContext(ctx)
+
+	q.mutex.Lock()
+	kq := q.queue(key)
+	now := q.clock.Now()
+
+	// A recent token left over by cancelled waiters goes to the next request
+	q.expireHeld(now)
+	if len(q.held) > 0 {
+		q.held = q.held[1:]
+		kq.record(0)
+		q.mutex.Unlock()
+		return nil
+	}
+
+	w := &fairWaiter{priority: PriorityFromContext(ctx), enqueued: now, ready: make(chan struct{})}
+	kq.waiters = append(kq.waiters, w)
+	if !kq.active {
+		kq.active = true
+		q.active = append(q.active, key)
+	}
+	if !q.running {
+		q.running = true
+		go q.dispatch()
+	}
+	q.mutex.Unlock()
+
+	select {
+	case <-w.ready:
+		q.mutex.Lock()
+		kq.record(w.granted.Sub(w.enqueued))
+		q.mutex.Unlock()
+		return nil
+	case <-ctx.Done():
+		q.mutex.Lock()
+		defer q.mutex.Unlock()
+
+		select {
+		case <-w.ready:
+			// The token was granted as the context ended, so pass it on
+			q.release(q.clock.Now())
+		default:
+			kq.remove(w)
+		}
+		return ctx.Err()
+	}
+}
+
+// SetWeight sets how many consecutive tokens key may be granted per round;
+// weights below 1 are treated as 1
+func (q *FairQueue) SetWeight(key string, weight int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if weight < 1 {
+		weight = 1
+	}
+	q.queue(key).weight = weight
+}
+
+// SetPriorityAging sets how long a request waits before it is promoted one
+// priority level; zero disables promotion
+func (q *FairQueue) SetPriorityAging(d time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.aging = d
+}
+
+// Stats returns the wait statistics of every key seen so far
+func (q *FairQueue) Stats() map[string]KeyStats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	stats := make(map[string]KeyStats, len(q.queues))
+	for key, kq := range q.queues {
+		stats[key] = kq.snapshot()
+	}
+	return stats
+}
+
+// KeyStats returns the wait statistics of a single key
+func (q *FairQueue) KeyStats(key string) KeyStats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if kq, ok := q.queues[key]; ok {
+		return kq.snapshot()
+	}
+	return KeyStats{Weight: 1}
+}
+
+// dispatch takes tokens from the limiter and grants them in round-robin
+// order until no requests are queued
+func (q *FairQueue) dispatch() {
+	for {
+		// The background context never expires, so Wait cannot fail
+		q.limiter.Wait(context.Background())
+
+		q.mutex.Lock()
+		now := q.clock.Now()
+		w := q.next(now)
+		if w == nil {
+			q.held = append(q.held, now)
+			q.running = false
+			q.mutex.Unlock()
+			return
+		}
+
+		w.grant(now)
+
+		if len(q.active) == 0 {
+			q.running = false
+			q.mutex.Unlock()
+			return
+		}
+		q.mutex.Unlock()
+	}
+}
+
+// next removes and returns the waiter to serve: the highest priority
+// request, taken from the first key in round-robin order that holds one
+// (must be called with mutex held)
+func (q *FairQueue) next(now time.Time) *fairWaiter {
+	q.prune()
+	if len(q.active) == 0 {
+		return nil
+	}
+	if q.turn >= len(q.active) {
+		q.turn = 0
+	}
+
+	// Find the best request of each key and the highest priority among them
+	best := make([]*fairWaiter, len(q.active))
+	top := Priority(math.MinInt)
+	for i, key := range q.active {
+		best[i] = q.queues[key].best(now, q.aging)
+		if p := best[i].effectivePriority(now, q.aging); p > top {
+			top = p
+		}
+	}
+
+	// Keys without a request of that priority lose their turn
+	for i := range q.active {
+		idx := (q.turn + i) % len(q.active)
+		w := best[idx]
+		if w.effectivePriority(now, q.aging) < top {
+			continue
+		}
+		if idx != q.turn {
+			q.turn = idx
+			q.served = 0
+		}
+
+		kq := q.queues[q.active[idx]]
+		kq.remove(w)
+		q.served++
+
+		switch {
+		case len(kq.waiters) == 0:
+			q.deactivate()
+		case q.served >= kq.weight:
+			q.turn++
+			q.served = 0
+		}
+
+		return w
+	}
+
+	return nil
+}
+
+// release passes a token granted to a cancelled request on to the next
+// queued request, or keeps it for the next caller (must be called with mutex held)
+func (q *FairQueue) release(now time.Time) {
+	if w := q.next(now); w != nil {
+		w.grant(now)
+		return
+	}
+	q.held = append(q.held, now)
+}
+
+// expireHeld drops held tokens taken a refill interval or more ago. The
+// limiter has refilled since, so spending them on top of its budget could
+// exceed its capacity (must be called with mutex held).
+func (q *FairQueue) expireHeld(now time.Time) {
+	if len(q.held) == 0 {
+		return
+	}
+
+	refillRate := q.limiter.GetState().RefillRate
+	expired := 0
+	for expired < len(q.held) && now.Sub(q.held[expired]) >= refillRate {
+		expired++
+	}
+	q.held = q.held[expired:]
+}
+
+// prune drops keys whose requests were all cancelled from the round-robin
+// ring (must be called with mutex held)
+func (q *FairQueue) prune() {
+	for i := 0; i < len(q.active); {
+		kq := q.queues[q.active[i]]
+		if len(kq.waiters) > 0 {
+			i++
+			continue
+		}
+
+		kq.active = false
+		q.active = append(q.active[:i], q.active[i+1:]...)
+		switch {
+		case i < q.turn:
+			q.turn--
+		case i == q.turn:
+			q.served = 0
+		}
+	}
+}
+
+// deactivate drops the current key from the round-robin ring (must be called with mutex held)
+func (q *FairQueue) deactivate() {
+	q.queues[q.active[q.turn]].active = false
+	q.active = append(q.active[:q.turn], q.active[q.turn+1:]...)
+	q.served = 0
+}
+
+// queue returns the queue of key, creating it if needed (must be called with mutex held)
+func (q *FairQueue) queue(key string) *keyQueue {
+	kq, ok := q.queues[key]
+	if !ok {
+		kq = &keyQueue{weight: 1}
+		q.queues[key] = kq
+	}
+	return kq
+}
+
+// record adds a granted request to the statistics
+func (kq *keyQueue) record(wait time.Duration) {
+	kq.stats.Requests++
+	kq.stats.TotalWait += wait
+	if wait > kq.stats.MaxWait {
+		kq.stats.MaxWait = wait
+	}
+}
+
+// best returns the waiter to serve first: the highest effective priority,
+// then the earliest arrival
+func (kq *keyQueue) best(now time.Time, aging time.Duration) *fairWaiter {
+	best := kq.waiters[0]
+	bestPriority := best.effectivePriority(now, aging)
+	for _, w := range kq.waiters[1:] {
+		if p := w.effectivePriority(now, aging); p > bestPriority {
+			best, bestPriority = w, p
+		}
+	}
+	return best
+}
+
+// remove drops a cancelled waiter, if it has not been granted yet
+func (kq *keyQueue) remove(w *fairWaiter) {
+	for i, queued := range kq.waiters {
+		if queued == w {
+			kq.waiters = append(kq.waiters[:i], kq.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// snapshot returns the statistics including the current queue length
+func (kq *keyQueue) snapshot() KeyStats {
+	stats := kq.stats
+	stats.Waiting = len(kq.waiters)
+	stats.Weight = kq.weight
+	return stats
+}
+
+// effectivePriority raises the waiter's priority by one level per aging interval waited
+func (w *fairWaiter) effectivePriority(now time.Time, aging time.Duration) Priority {
+	return agedPriority(w.priority, now.Sub(w.enqueued), aging)
+}
+
+// grant hands the token to the waiter (must be called with mutex held)
+func (w *fairWaiter) grant(now time.Time) {
+	w.granted = now
+	close(w.ready)
+}
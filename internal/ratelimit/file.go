@@ -0,0 +1,286 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileLimiter is a rate limiter whose state lives in a file guarded by an
+// advisory lock, so that several processes on the same host sharing one
+// agent token also share one request budget. It applies either the token
+// bucket or the dual window policy, with the same arithmetic as TokenBucket
+// and DualWindowLimiter. Waiters within a process are served by priority;
+// across processes, blocked waiters re-check the file whenever the next
+// request is due.
+//
+// If the state file cannot be read or written, requests are allowed rather
+// than blocked indefinitely; the error is available from Err and the
+// server-reported rate limit state still corrects the budget.
+type FileLimiter struct {
+	file      *os.File
+	policy    policy     // State as of the last file access
+	err       error      // Last error accessing the file
+	scheduler scheduler  // Queue of waiters in this process
+	mutex     sync.Mutex // Thread safety
+}
+
+// NewFileLimiter creates a token bucket matching NewTokenBucket whose
+// state is shared through the file at path, creating it if needed
+func NewFileLimiter(path string) (*FileLimiter, error) {
+	return NewCustomFileLimiter(path, 30, 500*time.Millisecond)
+}
+
+// NewCustomFileLimiter creates a shared token bucket with custom
+// parameters. All processes sharing path should use the same parameters.
+func NewCustomFileLimiter(path string, capacity int, refillRate time.Duration) (*FileLimiter, error) {
+	return newFileLimiter(path, newBucketPolicy(capacity, refillRate, time.Now()))
+}
+
+// NewDualWindowFileLimiter creates a limiter matching NewDualWindowLimiter
+// whose state is shared through the file at path, creating it if needed
+func NewDualWindowFileLimiter(path string) (*FileLimiter, error) {
+	return NewCustomDualWindowFileLimiter(path, 2, 30, 60*time.Second)
+}
+
+// NewCustomDualWindowFileLimiter creates a shared dual window limiter with
+// custom parameters. All processes sharing path should use the same
+// parameters.
+func NewCustomDualWindowFileLimiter(path string, perSecond, burst int, burstWindow time.Duration) (*FileLimiter, error) {
+	return newFileLimiter(path, newDualWindowPolicy(perSecond, burst, burstWindow, time.Now()))
+}
+
+// newFileLimiter opens the state file at path, applying p to the stored state
+func newFileLimiter(path string, p policy) (*FileLimiter, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rate limit state file: %w", err)
+	}
+
+	l := &FileLimiter{
+		file:   file,
+		policy: p,
+	}
+	l.scheduler = newScheduler(&l.mutex, SystemClock(), l)
+
+	// Load the shared state, initializing the file if this is the first process
+	if err := l.update(time.Now(), func() {}); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Wait blocks until a request is allowed or context is cancelled. Waiters
+// are served in order of the priority attached with WithPriority.
+func (l *FileLimiter) Wait(ctx context.Context) error {
+	return l.scheduler.wait(ctx)
+}
+
+// Allow checks if a request is allowed and consumes its allowance if so.
+// It never takes an allowance ahead of requests blocked in Wait in this
+// process.
+func (l *FileLimiter) Allow() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.scheduler.tryTake()
+}
+
+// TryAllow attempts to consume a request allowance without blocking
+func (l *FileLimiter) TryAllow() bool {
+	return l.Allow()
+}
+
+// Reset restores the full shared allowance and clears any pause
+func (l *FileLimiter) Reset() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.record(l.update(now, func() {
+		l.policy.reset(now)
+	}))
+	l.scheduler.dispatch(now)
+}
+
+// Reserve takes n shared requests now, borrowing against future refills if
+// needed, and returns a reservation reporting when they are all available
+func (l *FileLimiter) Reserve(n int) *Reservation {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	clock := l.scheduler.clock
+	now := clock.Now()
+
+	var readyAt time.Time
+	var refund func(time.Time, int)
+	ok := true
+	l.record(l.update(now, func() {
+		readyAt, refund, ok = l.policy.reserve(now, n)
+	}))
+	if !ok {
+		return rejectedReservation(n, clock)
+	}
+	if refund == nil {
+		// The state file is unusable, so the requests fail open
+		return newReservation(n, now, clock, func(int) {})
+	}
+
+	return newReservation(n, readyAt, clock, func(unused int) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		// The policy holds the freshly loaded shared state when refund runs
+		now := clock.Now()
+		l.record(l.update(now, func() {
+			refund(now, unused)
+		}))
+		l.scheduler.dispatch(now)
+	})
+}
+
+// SetRemaining lowers the shared allowance to the number of requests the
+// server reports as remaining
+func (l *FileLimiter) SetRemaining(remaining int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.record(l.update(now, func() {
+		l.policy.setRemaining(now, remaining)
+	}))
+}
+
+// PauseUntil stops every process sharing the file from taking requests before t
+func (l *FileLimiter) PauseUntil(t time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.record(l.update(time.Now(), func() {
+		l.policy.pauseUntil(t)
+	}))
+}
+
+// clockSource returns the clock of the waiters in this process
+func (l *FileLimiter) clockSource() Clock {
+	return l.scheduler.clock
+}
+
+// SetPriorityAging sets how long a waiter waits before it is promoted one
+// priority level; zero disables promotion
+func (l *FileLimiter) SetPriorityAging(d time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.scheduler.aging = d
+}
+
+// GetState returns the current shared state for monitoring
+func (l *FileLimiter) GetState() BucketState {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.record(l.update(now, func() {}))
+
+	state := l.policy.state(now)
+	state.Waiting = len(l.scheduler.waiters)
+	return state
+}
+
+// Err returns the last error accessing the state file, if any
+func (l *FileLimiter) Err() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.err
+}
+
+// Close closes the state file; the limiter must not be used afterwards
+func (l *FileLimiter) Close() error {
+	return l.file.Close()
+}
+
+// take consumes a shared request allowance if available (must be called with mutex held)
+func (l *FileLimiter) take(now time.Time) bool {
+	taken := false
+	err := l.update(now, func() {
+		taken = l.policy.take(now)
+	})
+	l.record(err)
+
+	// Fail open rather than block every request on a broken state file
+	return taken || err != nil
+}
+
+// availableIn returns how long until a request may be allowed, as of the
+// last file access (must be called with mutex held)
+func (l *FileLimiter) availableIn(now time.Time) time.Duration {
+	return l.policy.availableIn(now)
+}
+
+// record remembers the outcome of a file access (must be called with mutex held)
+func (l *FileLimiter) record(err error) {
+	if err != nil {
+		l.err = err
+	}
+}
+
+// update locks the state file, loads the stored state into the policy,
+// applies fn and writes the result back (must be called with mutex held)
+func (l *FileLimiter) update(now time.Time, fn func()) error {
+	if err := lockFile(l.file); err != nil {
+		return fmt.Errorf("failed to lock rate limit state file: %w", err)
+	}
+	defer unlockFile(l.file)
+
+	if err := l.read(now); err != nil {
+		return err
+	}
+
+	fn()
+
+	return l.write()
+}
+
+// read loads the stored state into the policy
+func (l *FileLimiter) read(now time.Time) error {
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read rate limit state file: %w", err)
+	}
+
+	data, err := io.ReadAll(l.file)
+	if err != nil {
+		return fmt.Errorf("failed to read rate limit state file: %w", err)
+	}
+
+	// A new file, or one left incomplete by a crashed process, starts full
+	if len(data) == 0 || json.Unmarshal(data, l.policy) != nil {
+		l.policy.reset(now)
+	}
+
+	return nil
+}
+
+// write replaces the stored state with the policy's
+func (l *FileLimiter) write() error {
+	data, err := json.Marshal(l.policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limit state: %w", err)
+	}
+
+	if err := l.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write rate limit state file: %w", err)
+	}
+	if _, err := l.file.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("failed to write rate limit state file: %w", err)
+	}
+
+	return nil
+}
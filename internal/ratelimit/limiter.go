@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Limiter is a rate limiter the transport waits on before each request and
+// corrects from the rate limit state reported by the server
+type Limiter interface {
+	// Wait blocks until a request is allowed or context is cancelled
+	Wait(ctx context.Context) error
+	// TryAllow consumes a request allowance without blocking, if available
+	TryAllow() bool
+	// Reset restores the full allowance and clears any pause
+	Reset()
+	// SetRemaining lowers the allowance to the server-reported remaining requests
+	SetRemaining(remaining int)
+	// PauseUntil stops requests from being allowed before t
+	PauseUntil(t time.Time)
+	// GetState returns the current state for monitoring
+	GetState() BucketState
+}
+
+// Ensure the limiters implement Limiter
+var (
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*DualWindowLimiter)(nil)
+	_ Limiter = (*FileLimiter)(nil)
+)
+
+// clocked is implemented by the limiters in this package, so that a
+// FairQueue built on one keeps time with it
+type clocked interface {
+	clockSource() Clock
+}
+
+// clockOf returns the clock of limiter, or the system clock if it does not expose one
+func clockOf(limiter Limiter) Clock {
+	if c, ok := limiter.(clocked); ok {
+		return c.clockSource()
+	}
+	return SystemClock()
+}
+
+// policy holds the state and arithmetic of a rate limit, shared by the
+// in-process limiters and FileLimiter, which stores it as JSON between
+// accesses. Methods are called with the owning limiter's mutex held and
+// bring the state up to date with now before acting.
+type policy interface {
+	json.Marshaler
+	json.Unmarshaler
+
+	// reset restores the full allowance and clears any pause
+	reset(now time.Time)
+	// take consumes one request allowance if available
+	take(now time.Time) bool
+	// availableIn returns how long after now take may succeed, as of the
+	// last update
+	availableIn(now time.Time) time.Duration
+	// reserve takes n requests, borrowing against future refills, and
+	// returns when they are all available and a function returning unused
+	// ones; ok is false if n is below 1 or exceeds what the policy can ever
+	// allow at once
+	reserve(now time.Time, n int) (readyAt time.Time, refund func(now time.Time, unused int), ok bool)
+	// setRemaining lowers the allowance to remaining requests
+	setRemaining(now time.Time, remaining int)
+	// pauseUntil stops requests from being allowed before t
+	pauseUntil(t time.Time)
+	// state returns the current state, without the number of waiters
+	state(now time.Time) BucketState
+}
+
+// Ensure the policies implement policy
+var (
+	_ policy = (*bucketPolicy)(nil)
+	_ policy = (*dualWindowPolicy)(nil)
+)
@@ -0,0 +1,20 @@
+//go:build !unix
+
+package ratelimit
+
+import (
+	"errors"
+	"os"
+)
+
+var errLockUnsupported = errors.New("file locking is not supported on this platform")
+
+// lockFile reports that shared state files cannot be locked on this platform
+func lockFile(f *os.File) error {
+	return errLockUnsupported
+}
+
+// unlockFile reports that shared state files cannot be locked on this platform
+func unlockFile(f *os.File) error {
+	return errLockUnsupported
+}
@@ -0,0 +1,212 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for the rate limiter; higher priorities
+// are served first, and requests of equal priority in arrival order
+type Priority int
+
+const (
+	// PriorityLow is for background work such as market scans
+	PriorityLow Priority = -1
+	// PriorityNormal is the default for requests without a priority
+	PriorityNormal Priority = 0
+	// PriorityHigh is for time-critical calls such as selling cargo
+	PriorityHigh Priority = 1
+)
+
+// DefaultPriorityAging is how long a request waits before it is promoted
+// one priority level, so low priority work is never starved indefinitely
+const DefaultPriorityAging = 10 * time.Second
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose rate limited requests wait with priority p
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority attached to ctx, or PriorityNormal
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// allowance is implemented by limiters whose requests are handed out by a scheduler.
+// Both methods are called with the limiter's mutex held.
+type allowance interface {
+	// take consumes one request allowance if available
+	take(now time.Time) bool
+	// availableIn returns how long until take may succeed
+	availableIn(now time.Time) time.Duration
+}
+
+// waiter is a request queued for an allowance
+type waiter struct {
+	priority Priority
+	enqueued time.Time
+	seq      uint64
+	ready    chan struct{} // Closed once the allowance is granted
+}
+
+// scheduler queues waiters for a limiter and grants allowances in priority
+// order as they become available, instead of letting waiters race
+type scheduler struct {
+	mutex     *sync.Mutex   // The owning limiter's mutex
+	clock     Clock         // Source of time and timers
+	allowance allowance     // The owning limiter
+	aging     time.Duration // Wait after which a waiter gains one priority level
+	waiters   []*waiter     // Queued waiters in arrival order
+	seq       uint64        // Sequence number of the next waiter
+	timerAt   time.Time     // Deadline of the pending dispatch timer, if any
+}
+
+// newScheduler creates a scheduler for a limiter guarded by mutex
+func newScheduler(mutex *sync.Mutex, clock Clock, a allowance) scheduler {
+	return scheduler{
+		mutex:     mutex,
+		clock:     clock,
+		allowance: a,
+		aging:     DefaultPriorityAging,
+	}
+}
+
+// wait blocks until the waiter is granted an allowance or ctx is cancelled
+func (s *scheduler) wait(ctx context.Context) error {
+	s.mutex.Lock()
+	now := s.clock.Now()
+
+	// Skip the queue only when nobody is waiting ahead
+	if len(s.waiters) == 0 && s.allowance.take(now) {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	w := &waiter{
+		priority: PriorityFromContext(ctx),
+		enqueued: now,
+		seq:      s.seq,
+		ready:    make(chan struct{}),
+	}
+	s.seq++
+	s.waiters = append(s.waiters, w)
+	s.dispatch(now)
+	s.mutex.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		select {
+		case <-w.ready:
+			// The allowance was granted as the context ended, so pass it on,
+			// or keep it for this request if nobody else is waiting
+			if len(s.waiters) == 0 {
+				return nil
+			}
+			s.grant(s.clock.Now())
+		default:
+			s.remove(w)
+		}
+		return ctx.Err()
+	}
+}
+
+// tryTake consumes an allowance without blocking, unless others are waiting (must be called with mutex held)
+func (s *scheduler) tryTake() bool {
+	if len(s.waiters) > 0 {
+		return false
+	}
+	return s.allowance.take(s.clock.Now())
+}
+
+// dispatch grants available allowances to the highest priority waiters and
+// schedules the next dispatch if any remain (must be called with mutex held)
+func (s *scheduler) dispatch(now time.Time) {
+	for len(s.waiters) > 0 && s.allowance.take(now) {
+		s.grant(now)
+	}
+
+	if len(s.waiters) > 0 {
+		s.schedule(now, s.allowance.availableIn(now))
+	}
+}
+
+// grant hands an allowance already taken to the highest priority waiter (must be called with mutex held)
+func (s *scheduler) grant(now time.Time) {
+	w := s.next(now)
+	s.remove(w)
+	close(w.ready)
+}
+
+// next returns the waiter to serve first: the highest effective priority,
+// then the earliest arrival (must be called with mutex held)
+func (s *scheduler) next(now time.Time) *waiter {
+	best := s.waiters[0]
+	bestPriority := s.effectivePriority(best, now)
+	for _, w := range s.waiters[1:] {
+		if p := s.effectivePriority(w, now); p > bestPriority {
+			best, bestPriority = w, p
+		}
+	}
+	return best
+}
+
+// effectivePriority raises a waiter's priority by one level per aging interval waited
+func (s *scheduler) effectivePriority(w *waiter, now time.Time) Priority {
+	return agedPriority(w.priority, now.Sub(w.enqueued), s.aging)
+}
+
+// agedPriority returns priority p raised by one level per aging interval
+// waited; an aging of zero disables promotion
+func agedPriority(p Priority, waited, aging time.Duration) Priority {
+	if aging <= 0 {
+		return p
+	}
+	return p + Priority(waited/aging)
+}
+
+// remove drops a waiter from the queue, keeping arrival order (must be called with mutex held)
+func (s *scheduler) remove(w *waiter) {
+	for i, queued := range s.waiters {
+		if queued == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// schedule arranges a dispatch after d, unless one is already due sooner (must be called with mutex held)
+func (s *scheduler) schedule(now time.Time, d time.Duration) {
+	if d <= 0 {
+		d = time.Millisecond // Minimum wait
+	}
+
+	deadline := now.Add(d)
+	if !s.timerAt.IsZero() && !deadline.Before(s.timerAt) {
+		return
+	}
+	s.timerAt = deadline
+
+	timer := s.clock.After(d)
+	go func() {
+		<-timer
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.timerAt.Equal(deadline) {
+			s.timerAt = time.Time{}
+		}
+		s.dispatch(s.clock.Now())
+	}()
+}
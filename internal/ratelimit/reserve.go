@@ -0,0 +1,200 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// InfDuration is the delay of a reservation that can never be satisfied
+const InfDuration = time.Duration(math.MaxInt64)
+
+// ErrExceedsCapacity is returned when more requests are reserved than the
+// limiter can ever provide at once
+var ErrExceedsCapacity = errors.New("reservation exceeds rate limiter capacity")
+
+// ErrInvalidReservation is returned when fewer than one request is reserved
+var ErrInvalidReservation = errors.New("reservation must be for at least one request")
+
+// Reserver is implemented by limiters that can reserve several requests
+// ahead of time
+type Reserver interface {
+	// Reserve claims n requests and reports when they may all be sent. The
+	// reservation is not OK if n is below 1 or exceeds the limiter's capacity.
+	Reserve(n int) *Reservation
+}
+
+// Ensure the limiters implement Reserver
+var (
+	_ Reserver = (*TokenBucket)(nil)
+	_ Reserver = (*DualWindowLimiter)(nil)
+	_ Reserver = (*FileLimiter)(nil)
+)
+
+// Reservation holds requests claimed from a limiter ahead of time, similar
+// to golang.org/x/time/rate reservations. The requests are taken from the
+// limiter immediately, borrowing against future refills if necessary, and
+// are spent by requests made with a context from WithReservation instead of
+// waiting on the limiter again.
+type Reservation struct {
+	ok      bool
+	tokens  int
+	used    int // Reserved requests already spent
+	readyAt time.Time
+	clock   Clock
+	refund  func(n int) // Returns n reserved requests to the limiter
+	done    bool        // Whether the reservation was cancelled
+	mutex   sync.Mutex
+}
+
+// newReservation creates a reservation of tokens requests available at readyAt
+func newReservation(tokens int, readyAt time.Time, clock Clock, refund func(n int)) *Reservation {
+	return &Reservation{
+		ok:      true,
+		tokens:  tokens,
+		readyAt: readyAt,
+		clock:   clock,
+		refund:  refund,
+	}
+}
+
+// rejectedReservation creates a reservation of tokens requests that can never be satisfied
+func rejectedReservation(tokens int, clock Clock) *Reservation {
+	return &Reservation{tokens: tokens, clock: clock}
+}
+
+type reservationKey struct{}
+
+// WithReservation returns a context whose requests spend the requests held
+// by r, falling back to the limiter once they are used up
+func WithReservation(ctx context.Context, r *Reservation) context.Context {
+	return context.WithValue(ctx, reservationKey{}, r)
+}
+
+// ReservationFromContext returns the reservation attached to ctx, if any
+func ReservationFromContext(ctx context.Context) *Reservation {
+	r, _ := ctx.Value(reservationKey{}).(*Reservation)
+	return r
+}
+
+// OK reports whether the limiter can ever provide the requested requests;
+// a reservation of no requests, or more than the limiter's capacity, is not OK
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+// Tokens returns the number of reserved requests
+func (r *Reservation) Tokens() int {
+	return r.tokens
+}
+
+// Remaining returns the number of reserved requests not yet spent or cancelled
+func (r *Reservation) Remaining() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.ok || r.done {
+		return 0
+	}
+	return r.tokens - r.used
+}
+
+// ReadyAt returns the time at which all reserved requests may be sent
+func (r *Reservation) ReadyAt() time.Time {
+	return r.readyAt
+}
+
+// Delay returns how long to wait before sending the reserved requests
+func (r *Reservation) Delay() time.Duration {
+	return r.DelayFrom(r.clock.Now())
+}
+
+// DelayFrom returns how long after t the reserved requests may be sent
+func (r *Reservation) DelayFrom(t time.Time) time.Duration {
+	if !r.ok {
+		return InfDuration
+	}
+
+	delay := r.readyAt.Sub(t)
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Cancel returns the reserved requests that have not been spent to the
+// limiter; it is safe to call more than once
+func (r *Reservation) Cancel() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.ok || r.done {
+		return
+	}
+
+	r.done = true
+	if unused := r.tokens - r.used; unused > 0 {
+		r.refund(unused)
+	}
+}
+
+// Wait blocks until the reserved requests may be sent. If ctx is cancelled
+// first, the reservation is cancelled and ctx's error returned.
+func (r *Reservation) Wait(ctx context.Context) error {
+	if !r.ok {
+		if r.tokens < 1 {
+			return ErrInvalidReservation
+		}
+		return ErrExceedsCapacity
+	}
+
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	select {
+	case <-r.clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
+
+// Use spends one reserved request, waiting until the reservation is ready.
+// It reports false if no reserved requests remain, in which case the caller
+// should wait on the limiter instead.
+func (r *Reservation) Use(ctx context.Context) (bool, error) {
+	r.mutex.Lock()
+	if !r.ok || r.done || r.used >= r.tokens {
+		r.mutex.Unlock()
+		return false, nil
+	}
+	r.used++
+	r.mutex.Unlock()
+
+	delay := r.Delay()
+	if delay == 0 {
+		return true, nil
+	}
+
+	select {
+	case <-r.clock.After(delay):
+		return true, nil
+	case <-ctx.Done():
+		// The request is not sent, so its reservation is still available,
+		// or is returned directly if the rest were cancelled meanwhile
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		if r.done {
+			r.refund(1)
+		} else {
+			r.used--
+		}
+		return true, ctx.Err()
+	}
+}
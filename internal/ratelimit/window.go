@@ -0,0 +1,459 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DualWindowLimiter implements the SpaceTraders rate limit policy: a static
+// limit of 2 requests per second, plus a burst pool of 30 requests that
+// refills over a 60 second window. Requests spend the static allowance
+// first and only draw on the burst pool once it is exhausted.
+type DualWindowLimiter struct {
+	policy    *dualWindowPolicy // State and arithmetic of both windows
+	clock     Clock             // Source of time, injectable for tests
+	scheduler scheduler         // Queue of waiters, served by priority
+	mutex     sync.Mutex        // Thread safety
+}
+
+// NewDualWindowLimiter creates a limiter matching the SpaceTraders API:
+// 2 requests per second, plus a 30 request burst over a 60 second window
+func NewDualWindowLimiter() *DualWindowLimiter {
+	return NewCustomDualWindowLimiter(2, 30, 60*time.Second, SystemClock())
+}
+
+// NewCustomDualWindowLimiter creates a dual window limiter with custom
+// parameters. A nil clock uses the system clock.
+func NewCustomDualWindowLimiter(perSecond, burst int, burstWindow time.Duration, clock Clock) *DualWindowLimiter {
+	if clock == nil {
+		clock = SystemClock()
+	}
+
+	l := &DualWindowLimiter{
+		policy: newDualWindowPolicy(perSecond, burst, burstWindow, clock.Now()),
+		clock:  clock,
+	}
+	l.scheduler = newScheduler(&l.mutex, clock, l.policy)
+	return l
+}
+
+// Wait blocks until a request is allowed or context is cancelled. Waiters
+// are served in order of the priority attached with WithPriority, and in
+// arrival order within a priority.
+func (l *DualWindowLimiter) Wait(ctx context.Context) error {
+	return l.scheduler.wait(ctx)
+}
+
+// Allow checks if a request is allowed and consumes its allowance if so.
+// It never takes an allowance ahead of requests blocked in Wait.
+func (l *DualWindowLimiter) Allow() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.scheduler.tryTake()
+}
+
+// TryAllow attempts to consume a request allowance without blocking
+func (l *DualWindowLimiter) TryAllow() bool {
+	return l.Allow()
+}
+
+// clockSource returns the clock the windows refill by
+func (l *DualWindowLimiter) clockSource() Clock {
+	return l.clock
+}
+
+// SetPriorityAging sets how long a waiter waits before it is promoted one
+// priority level; zero disables promotion
+func (l *DualWindowLimiter) SetPriorityAging(d time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.scheduler.aging = d
+}
+
+// Reset refills both windows and clears any pause
+func (l *DualWindowLimiter) Reset() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock.Now()
+	l.policy.reset(now)
+	l.scheduler.dispatch(now)
+}
+
+// Reserve takes n requests now and returns a reservation reporting when
+// they are all available. Requests beyond what both windows currently allow
+// are borrowed against the static window's future refills.
+func (l *DualWindowLimiter) Reserve(n int) *Reservation {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	readyAt, refund, ok := l.policy.reserve(l.clock.Now(), n)
+	if !ok {
+		return rejectedReservation(n, l.clock)
+	}
+
+	return newReservation(n, readyAt, l.clock, func(unused int) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		now := l.clock.Now()
+		refund(now, unused)
+		l.scheduler.dispatch(now)
+	})
+}
+
+// SetRemaining corrects the available requests from the number the server
+// reports as remaining, draining the burst pool before the static limit.
+// The count is only ever lowered.
+func (l *DualWindowLimiter) SetRemaining(remaining int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.policy.setRemaining(l.clock.Now(), remaining)
+}
+
+// PauseUntil stops the limiter from allowing requests before t, e.g. after
+// the server rejected a request with 429 Too Many Requests
+func (l *DualWindowLimiter) PauseUntil(t time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.policy.pauseUntil(t)
+}
+
+// GetState returns the combined state of both windows for monitoring.
+// LastRefill is reported so that AvailableIn points at the next allowance.
+func (l *DualWindowLimiter) GetState() BucketState {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	state := l.policy.state(l.clock.Now())
+	state.Waiting = len(l.scheduler.waiters)
+	return state
+}
+
+// GetWindowStates returns the state of the static and burst windows separately
+func (l *DualWindowLimiter) GetWindowStates() (static, burst BucketState) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.policy.windowStates(l.clock.Now())
+}
+
+// dualWindowPolicy is the arithmetic of the static and burst windows
+type dualWindowPolicy struct {
+	static      window    // Per-second static limit
+	burst       window    // Burst pool refilled over the burst window
+	pausedUntil time.Time // No requests are allowed before this time
+}
+
+// dualWindowJSON is the stored form of a dualWindowPolicy
+type dualWindowJSON struct {
+	Static      windowJSON `json:"static"`
+	Burst       windowJSON `json:"burst"`
+	PausedUntil time.Time  `json:"paused_until,omitempty"`
+}
+
+// windowJSON is the stored form of a window
+type windowJSON struct {
+	Credit int64     `json:"credit"`
+	Last   time.Time `json:"last"`
+}
+
+// newDualWindowPolicy creates a policy with both windows full
+func newDualWindowPolicy(perSecond, burst int, burstWindow time.Duration, now time.Time) *dualWindowPolicy {
+	return &dualWindowPolicy{
+		static: newWindow(perSecond, time.Second, now),
+		burst:  newWindow(burst, burstWindow, now),
+	}
+}
+
+// reset refills both windows and clears any pause
+func (p *dualWindowPolicy) reset(now time.Time) {
+	p.static = newWindow(p.static.limit, p.static.period, now)
+	p.burst = newWindow(p.burst.limit, p.burst.period, now)
+	p.pausedUntil = time.Time{}
+}
+
+// advance accrues the credit of both windows up to now
+func (p *dualWindowPolicy) advance(now time.Time) {
+	p.static.advance(now)
+	p.burst.advance(now)
+}
+
+// take consumes one request allowance if available
+func (p *dualWindowPolicy) take(now time.Time) bool {
+	if now.Before(p.pausedUntil) {
+		return false
+	}
+
+	p.advance(now)
+
+	switch {
+	case p.static.tokens() > 0:
+		p.static.spend()
+	case p.burst.tokens() > 0:
+		p.burst.spend()
+	default:
+		return false
+	}
+
+	return true
+}
+
+// availableIn returns how long until a request will be allowed
+func (p *dualWindowPolicy) availableIn(now time.Time) time.Duration {
+	waitTime := p.nextRefill()
+	if pause := p.pausedUntil.Sub(now); pause > waitTime {
+		waitTime = pause
+	}
+
+	return waitTime
+}
+
+// nextRefill returns how long until either window has an allowance
+func (p *dualWindowPolicy) nextRefill() time.Duration {
+	waitTime := p.static.availableIn()
+	if burstWait := p.burst.availableIn(); burstWait < waitTime {
+		waitTime = burstWait
+	}
+
+	return waitTime
+}
+
+// reserve spends the static window, then the burst pool, then borrows
+// against the static window's refills (or the burst pool's, if there is
+// no static limit)
+func (p *dualWindowPolicy) reserve(now time.Time, n int) (time.Time, func(time.Time, int), bool) {
+	if n < 1 || n > p.static.limit+p.burst.limit {
+		return time.Time{}, nil, false
+	}
+
+	p.advance(now)
+
+	lender := &p.static
+	if lender.limit == 0 {
+		lender = &p.burst
+	}
+
+	var fromStatic int
+	for i := 0; i < n; i++ {
+		switch {
+		case p.static.tokens() > 0:
+			p.static.spend()
+			fromStatic++
+		case p.burst.tokens() > 0:
+			p.burst.spend()
+		case lender == &p.static:
+			p.static.spend()
+			fromStatic++
+		default:
+			p.burst.spend()
+		}
+	}
+
+	readyAt := now.Add(lender.debtIn())
+	if p.pausedUntil.After(readyAt) {
+		readyAt = p.pausedUntil
+	}
+
+	return readyAt, func(now time.Time, unused int) {
+		p.advance(now)
+
+		// Return borrowed static requests first, as they are what delays others
+		refundStatic := min(unused, fromStatic)
+		p.static.refund(refundStatic)
+		p.burst.refund(unused - refundStatic)
+	}, true
+}
+
+// setRemaining lowers the available requests to remaining, draining the
+// burst pool before the static limit
+func (p *dualWindowPolicy) setRemaining(now time.Time, remaining int) {
+	p.advance(now)
+
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	excess := p.static.tokens() + p.burst.tokens() - remaining
+	for ; excess > 0 && p.burst.tokens() > 0; excess-- {
+		p.burst.spend()
+	}
+	for ; excess > 0 && p.static.tokens() > 0; excess-- {
+		p.static.spend()
+	}
+}
+
+// pauseUntil stops requests from being allowed before t
+func (p *dualWindowPolicy) pauseUntil(t time.Time) {
+	if t.After(p.pausedUntil) {
+		p.pausedUntil = t
+	}
+}
+
+// state returns both windows combined as a BucketState
+func (p *dualWindowPolicy) state(now time.Time) BucketState {
+	p.advance(now)
+
+	refillRate := p.static.interval()
+	state := BucketState{
+		Tokens:     p.static.tokens() + p.burst.tokens(),
+		Capacity:   p.static.limit + p.burst.limit,
+		LastRefill: now.Add(p.nextRefill() - refillRate),
+		RefillRate: refillRate,
+	}
+	if now.Before(p.pausedUntil) {
+		state.PausedUntil = p.pausedUntil
+	}
+
+	return state
+}
+
+// windowStates returns the static and burst windows as BucketStates
+func (p *dualWindowPolicy) windowStates(now time.Time) (static, burst BucketState) {
+	p.advance(now)
+
+	return p.static.state(now), p.burst.state(now)
+}
+
+// MarshalJSON stores the credit of both windows and the pause
+func (p *dualWindowPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dualWindowJSON{
+		Static:      windowJSON{Credit: p.static.credit, Last: p.static.last},
+		Burst:       windowJSON{Credit: p.burst.credit, Last: p.burst.last},
+		PausedUntil: p.pausedUntil,
+	})
+}
+
+// UnmarshalJSON loads the state stored by MarshalJSON
+func (p *dualWindowPolicy) UnmarshalJSON(data []byte) error {
+	var stored dualWindowJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	p.static.credit, p.static.last = stored.Static.Credit, stored.Static.Last
+	p.burst.credit, p.burst.last = stored.Burst.Credit, stored.Burst.Last
+	p.pausedUntil = stored.PausedUntil
+	return nil
+}
+
+// window tracks one limit of limit requests per period using exact integer
+// credit: it earns limit units per elapsed nanosecond and each request costs
+// period units, so partial refills carry over without rounding
+type window struct {
+	limit  int           // Requests allowed per period
+	period time.Duration // Length of the window
+	credit int64         // Accrued credit, at most limit * period
+	last   time.Time     // Time credit was last accrued
+}
+
+// newWindow creates a full window
+func newWindow(limit int, period time.Duration, now time.Time) window {
+	if limit < 0 {
+		limit = 0
+	}
+
+	return window{
+		limit:  limit,
+		period: period,
+		credit: int64(limit) * int64(period),
+		last:   now,
+	}
+}
+
+// advance accrues credit for the time elapsed since the last call
+func (w *window) advance(now time.Time) {
+	elapsed := now.Sub(w.last)
+	if elapsed <= 0 {
+		return
+	}
+	w.last = now
+
+	if w.limit == 0 {
+		return
+	}
+
+	// Check the elapsed time against what is missing first, so that long
+	// idle periods cannot overflow the credit
+	full := int64(w.limit) * int64(w.period)
+	if int64(elapsed) >= (full-w.credit)/int64(w.limit)+1 {
+		w.credit = full
+		return
+	}
+
+	w.credit += int64(elapsed) * int64(w.limit)
+	if w.credit > full {
+		w.credit = full
+	}
+}
+
+// tokens returns the number of whole requests currently allowed
+func (w *window) tokens() int {
+	if w.period <= 0 || w.credit <= 0 {
+		return 0
+	}
+	return int(w.credit / int64(w.period))
+}
+
+// spend consumes one request's worth of credit, possibly going into debt
+func (w *window) spend() {
+	w.credit -= int64(w.period)
+}
+
+// refund returns the credit of n spent requests
+func (w *window) refund(n int) {
+	w.credit += int64(n) * int64(w.period)
+	if full := int64(w.limit) * int64(w.period); w.credit > full {
+		w.credit = full
+	}
+}
+
+// debtIn returns how long until the window is out of debt
+func (w *window) debtIn() time.Duration {
+	if w.credit >= 0 || w.limit == 0 {
+		return 0
+	}
+
+	limit := int64(w.limit)
+	return time.Duration((-w.credit + limit - 1) / limit)
+}
+
+// interval returns the average time between refilled requests
+func (w *window) interval() time.Duration {
+	if w.limit == 0 {
+		return w.period
+	}
+	return w.period / time.Duration(w.limit)
+}
+
+// availableIn returns how long until the window allows another request
+func (w *window) availableIn() time.Duration {
+	missing := int64(w.period) - w.credit
+	if missing <= 0 {
+		return 0
+	}
+	if w.limit == 0 {
+		return w.period
+	}
+
+	// Round up so that the credit is complete once the duration has elapsed
+	limit := int64(w.limit)
+	return time.Duration((missing + limit - 1) / limit)
+}
+
+// state returns the window as a BucketState
+func (w *window) state(now time.Time) BucketState {
+	refillRate := w.interval()
+	return BucketState{
+		Tokens:     w.tokens(),
+		Capacity:   w.limit,
+		LastRefill: now.Add(w.availableIn() - refillRate),
+		RefillRate: refillRate,
+	}
+}
@@ -74,16 +74,11 @@ func (a *AuthManager) RegisterAgent(ctx context.Context, callSign, faction strin
 		return nil, fmt.Errorf("registration request failed: %w", err)
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.RegisterAgentResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal registration response: %w", err)
 	}
-
-	// Parse the registration response data
-	regRespData, err := parseRegistrationResponse(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse registration data: %w", err)
-	}
+	regRespData := &apiResp.Data
 
 	// Store authentication data
 	a.mutex.Lock()
@@ -143,15 +138,11 @@ func (a *AuthManager) GetAgent(ctx context.Context) (*schema.Agent, error) {
 		return nil, fmt.Errorf("failed to get agent: %w", err)
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.Agent]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal agent response: %w", err)
 	}
-
-	agent, err := parseAgentData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse agent data: %w", err)
-	}
+	agent := &apiResp.Data
 
 	// Cache the agent data
 	a.mutex.Lock()
@@ -258,36 +249,6 @@ func (a *AuthManager) isTokenExpired() bool {
 	return false
 }
 
-// parseRegistrationResponse parses the registration response data
-func parseRegistrationResponse(data interface{}) (*schema.RegisterAgentResponse, error) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-
-	var regResp schema.RegisterAgentResponse
-	if err := json.Unmarshal(jsonData, &regResp); err != nil {
-		return nil, err
-	}
-
-	return &regResp, nil
-}
-
-// parseAgentData parses agent data from API response
-func parseAgentData(data interface{}) (*schema.Agent, error) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-
-	var agent schema.Agent
-	if err := json.Unmarshal(jsonData, &agent); err != nil {
-		return nil, err
-	}
-
-	return &agent, nil
-}
-
 // TokenInfo represents information about the current token
 type TokenInfo struct {
 	HasToken    bool          `json:"has_token"`
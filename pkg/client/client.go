@@ -37,11 +37,17 @@
 //
 // The client provides comprehensive support for:
 //   - Agent registration and management
+//   - Server status, leaderboards and public agent listings
 //   - Fleet operations (ships, navigation, fuel)
+//   - Inter-system travel via jump gates and warp drives
 //   - Market operations (buying, selling cargo)
+//   - Shipyard browsing and ship purchasing
+//   - Ship outfitting, repair and scrapping
 //   - Contract management and fulfillment
 //   - System and waypoint exploration
+//   - Jump gate construction supply
 //   - Mining and survey operations
+//   - Scanning and waypoint charting
 //   - Faction information
 //   - Authentication and rate limiting
 //
@@ -52,22 +58,29 @@
 //   - Mock server for testing and development
 //   - Thread-safe operations with proper synchronization
 //   - Configurable HTTP timeouts and retry logic
+//   - Response metadata (pagination, rate limit headers, latency) via WithResponse
 package client
 
 import (
 	"context"
+	"fmt"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/auth"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/endpoints"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"io"
+	"net/http"
 	"time"
 )
 
 // SpaceTradersClient represents the main API client
 type SpaceTradersClient struct {
-	auth      *auth.AuthManager
-	endpoints *endpoints.EndpointManager
-	config    *Config
+	auth       *auth.AuthManager
+	endpoints  *endpoints.EndpointManager
+	httpClient *transport.HTTPClient
+	config     *Config
+	closers    []io.Closer // Resources opened by New, released by Close
 }
 
 // Config represents client configuration
@@ -76,6 +89,41 @@ type Config struct {
 	Timeout   time.Duration
 	UserAgent string
 	Token     string // Optional: pre-existing token
+
+	// RetryPolicy overrides the transport's default retry policy when set
+	RetryPolicy *transport.RetryPolicy
+
+	// RateLimiter replaces the default per-process rate limiter when set,
+	// e.g. with transport.NewDualWindowLimiter
+	RateLimiter transport.Limiter
+	// RateLimitStateFile shares the rate limit with other processes on this
+	// host using the same file, e.g. several bots under one agent token;
+	// it is ignored when RateLimiter is set
+	RateLimitStateFile string
+
+	// FairShare shares the rate limit between ships in round-robin order;
+	// requests are attributed to a ship with transport.WithRateLimitKey
+	FairShare bool
+
+	// Middleware wraps every request attempt, e.g. for logging or metrics
+	Middleware []transport.Middleware
+
+	// HTTPClient is used as-is for all requests when set, taking precedence
+	// over Timeout, Transport and the connection settings below
+	HTTPClient *http.Client
+	// Transport is used for requests when set, e.g. for proxies, TLS settings
+	// or test transports. Close leaves the idle connections of a caller's
+	// client or transport open.
+	Transport http.RoundTripper
+
+	// Connection pool tuning for the default transport; zero values keep the
+	// transport defaults
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DisableKeepAlives   bool
 }
 
 // DefaultConfig returns a default client configuration
@@ -98,6 +146,43 @@ func New(config *Config) (*SpaceTradersClient, error) {
 	httpConfig.BaseURL = config.BaseURL
 	httpConfig.Timeout = config.Timeout
 	httpConfig.UserAgent = config.UserAgent
+	if config.RetryPolicy != nil {
+		httpConfig.RetryPolicy = config.RetryPolicy
+	}
+	var closers []io.Closer
+	switch {
+	case config.RateLimiter != nil:
+		httpConfig.RateLimiter = config.RateLimiter
+	case config.RateLimitStateFile != "":
+		limiter, err := transport.NewSharedLimiter(config.RateLimitStateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create shared rate limiter: %w", err)
+		}
+		httpConfig.RateLimiter = limiter
+		if closer, ok := limiter.(io.Closer); ok {
+			closers = append(closers, closer)
+		}
+	}
+	httpConfig.FairShare = config.FairShare
+	httpConfig.Middleware = config.Middleware
+	httpConfig.HTTPClient = config.HTTPClient
+	httpConfig.Transport = config.Transport
+	if config.MaxIdleConns > 0 {
+		httpConfig.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		httpConfig.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		httpConfig.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		httpConfig.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.KeepAlive != 0 {
+		httpConfig.KeepAlive = config.KeepAlive
+	}
+	httpConfig.DisableKeepAlives = config.DisableKeepAlives
 	httpClient := transport.NewHTTPClient(httpConfig)
 
 	// Create auth manager
@@ -111,9 +196,11 @@ func New(config *Config) (*SpaceTradersClient, error) {
 	endpointManager := endpoints.NewEndpointManager(httpClient)
 
 	return &SpaceTradersClient{
-		auth:      authManager,
-		endpoints: endpointManager,
-		config:    config,
+		auth:       authManager,
+		endpoints:  endpointManager,
+		httpClient: httpClient,
+		config:     config,
+		closers:    closers,
 	}, nil
 }
 
@@ -144,13 +231,48 @@ func (c *SpaceTradersClient) IsAuthenticated() bool {
 	return c.auth.IsAuthenticated()
 }
 
+// GetServerStatus retrieves the server status, leaderboards and next scheduled reset
+func (c *SpaceTradersClient) GetServerStatus(ctx context.Context) (*schema.ServerStatus, error) {
+	return c.endpoints.GetServerStatus(ctx)
+}
+
+// ListAgents retrieves one page of the public details of all agents
+func (c *SpaceTradersClient) ListAgents(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Agent, error) {
+	return c.endpoints.ListAgents(ctx, opts)
+}
+
+// Agents returns an iterator over the public details of every agent, following pagination
+func (c *SpaceTradersClient) Agents(ctx context.Context, opts *schema.PaginationOptions) *endpoints.Iterator[schema.Agent] {
+	return c.endpoints.Agents(ctx, opts)
+}
+
+// ListAllAgents retrieves the public details of every agent across all pages
+func (c *SpaceTradersClient) ListAllAgents(ctx context.Context) ([]schema.Agent, error) {
+	return c.endpoints.Agents(ctx, nil).All()
+}
+
+// GetPublicAgent retrieves the public details of any agent by symbol
+func (c *SpaceTradersClient) GetPublicAgent(ctx context.Context, agentSymbol string) (*schema.Agent, error) {
+	return c.endpoints.GetPublicAgent(ctx, agentSymbol)
+}
+
 // Ship Operations
 
-// GetFleet retrieves all ships owned by the agent
+// GetFleet retrieves one page of ships owned by the agent
 func (c *SpaceTradersClient) GetFleet(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Ship, error) {
 	return c.endpoints.GetFleet(ctx, opts)
 }
 
+// Ships returns an iterator over every ship owned by the agent, following pagination
+func (c *SpaceTradersClient) Ships(ctx context.Context, opts *schema.PaginationOptions) *endpoints.Iterator[schema.Ship] {
+	return c.endpoints.Ships(ctx, opts)
+}
+
+// ListAllShips retrieves every ship owned by the agent across all pages
+func (c *SpaceTradersClient) ListAllShips(ctx context.Context) ([]schema.Ship, error) {
+	return c.endpoints.Ships(ctx, nil).All()
+}
+
 // GetShip retrieves information about a specific ship
 func (c *SpaceTradersClient) GetShip(ctx context.Context, shipSymbol string) (*schema.Ship, error) {
 	return c.endpoints.GetShip(ctx, shipSymbol)
@@ -171,11 +293,31 @@ func (c *SpaceTradersClient) RefuelShip(ctx context.Context, shipSymbol string)
 	return c.endpoints.RefuelShip(ctx, shipSymbol)
 }
 
-// NavigateShip navigates a ship to a waypoint
-func (c *SpaceTradersClient) NavigateShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.Navigation, error) {
+// NavigateShip navigates a ship to a waypoint and returns its updated nav, fuel and condition events
+func (c *SpaceTradersClient) NavigateShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.NavigateResult, error) {
 	return c.endpoints.NavigateShip(ctx, shipSymbol, waypointSymbol)
 }
 
+// SetFlightMode changes a ship's flight mode, e.g. schema.FlightModeDrift
+func (c *SpaceTradersClient) SetFlightMode(ctx context.Context, shipSymbol, flightMode string) (*schema.NavigateResult, error) {
+	return c.endpoints.SetFlightMode(ctx, shipSymbol, flightMode)
+}
+
+// GetShipCooldown gets the reactor cooldown of a ship, or nil if the ship has no active cooldown
+func (c *SpaceTradersClient) GetShipCooldown(ctx context.Context, shipSymbol string) (*schema.Cooldown, error) {
+	return c.endpoints.GetShipCooldown(ctx, shipSymbol)
+}
+
+// JumpShip jumps a ship to a connected jump gate, consuming antimatter
+func (c *SpaceTradersClient) JumpShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.JumpShipResponse, error) {
+	return c.endpoints.JumpShip(ctx, shipSymbol, waypointSymbol)
+}
+
+// WarpShip warps a ship to a waypoint in another system and returns its updated nav, fuel and condition events
+func (c *SpaceTradersClient) WarpShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.NavigateResult, error) {
+	return c.endpoints.WarpShip(ctx, shipSymbol, waypointSymbol)
+}
+
 // GetShipNav gets the navigation information for a ship
 func (c *SpaceTradersClient) GetShipNav(ctx context.Context, shipSymbol string) (*schema.Navigation, error) {
 	return c.endpoints.GetShipNav(ctx, shipSymbol)
@@ -186,6 +328,63 @@ func (c *SpaceTradersClient) GetShipCargo(ctx context.Context, shipSymbol string
 	return c.endpoints.GetShipCargo(ctx, shipSymbol)
 }
 
+// JettisonCargo jettisons cargo from a ship and returns its updated cargo
+func (c *SpaceTradersClient) JettisonCargo(ctx context.Context, shipSymbol string, req *schema.JettisonCargoRequest) (*schema.Cargo, error) {
+	return c.endpoints.JettisonCargo(ctx, shipSymbol, req)
+}
+
+// TransferCargo transfers cargo from one ship to another at the same waypoint
+func (c *SpaceTradersClient) TransferCargo(ctx context.Context, shipSymbol string, req *schema.TransferCargoRequest) (*schema.TransferCargoResponse, error) {
+	return c.endpoints.TransferCargo(ctx, shipSymbol, req)
+}
+
+// RefineCargo refines raw goods in a ship's cargo into the requested good
+func (c *SpaceTradersClient) RefineCargo(ctx context.Context, shipSymbol string, req *schema.RefineCargoRequest) (*schema.RefineCargoResponse, error) {
+	return c.endpoints.RefineCargo(ctx, shipSymbol, req)
+}
+
+// Ship Outfitting Operations
+
+// InstallMount installs a mount from the ship's cargo at a shipyard
+func (c *SpaceTradersClient) InstallMount(ctx context.Context, shipSymbol, mountSymbol string) (*schema.ShipMountsResponse, error) {
+	return c.endpoints.InstallMount(ctx, shipSymbol, mountSymbol)
+}
+
+// RemoveMount removes a mount from a ship at a shipyard, placing it in the ship's cargo
+func (c *SpaceTradersClient) RemoveMount(ctx context.Context, shipSymbol, mountSymbol string) (*schema.ShipMountsResponse, error) {
+	return c.endpoints.RemoveMount(ctx, shipSymbol, mountSymbol)
+}
+
+// InstallModule installs a module from the ship's cargo at a shipyard
+func (c *SpaceTradersClient) InstallModule(ctx context.Context, shipSymbol, moduleSymbol string) (*schema.ShipModulesResponse, error) {
+	return c.endpoints.InstallModule(ctx, shipSymbol, moduleSymbol)
+}
+
+// RemoveModule removes a module from a ship at a shipyard, placing it in the ship's cargo
+func (c *SpaceTradersClient) RemoveModule(ctx context.Context, shipSymbol, moduleSymbol string) (*schema.ShipModulesResponse, error) {
+	return c.endpoints.RemoveModule(ctx, shipSymbol, moduleSymbol)
+}
+
+// GetRepairQuote retrieves the cost of repairing a ship at its current shipyard
+func (c *SpaceTradersClient) GetRepairQuote(ctx context.Context, shipSymbol string) (*schema.RepairTransaction, error) {
+	return c.endpoints.GetRepairQuote(ctx, shipSymbol)
+}
+
+// RepairShip repairs a ship at its current shipyard
+func (c *SpaceTradersClient) RepairShip(ctx context.Context, shipSymbol string) (*schema.RepairShipResponse, error) {
+	return c.endpoints.RepairShip(ctx, shipSymbol)
+}
+
+// GetScrapQuote retrieves the value of scrapping a ship at its current shipyard
+func (c *SpaceTradersClient) GetScrapQuote(ctx context.Context, shipSymbol string) (*schema.ScrapTransaction, error) {
+	return c.endpoints.GetScrapQuote(ctx, shipSymbol)
+}
+
+// ScrapShip scraps a ship at its current shipyard, removing it from the fleet
+func (c *SpaceTradersClient) ScrapShip(ctx context.Context, shipSymbol string) (*schema.ScrapShipResponse, error) {
+	return c.endpoints.ScrapShip(ctx, shipSymbol)
+}
+
 // Market Operations
 
 // GetMarket retrieves market information for a waypoint
@@ -203,79 +402,208 @@ func (c *SpaceTradersClient) SellCargo(ctx context.Context, shipSymbol string, r
 	return c.endpoints.SellCargo(ctx, shipSymbol, req)
 }
 
+// Shipyard Operations
+
+// GetShipyard retrieves shipyard information for a waypoint
+func (c *SpaceTradersClient) GetShipyard(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Shipyard, error) {
+	return c.endpoints.GetShipyard(ctx, systemSymbol, waypointSymbol)
+}
+
+// PurchaseShip purchases a ship and returns the new ship, updated agent and transaction
+func (c *SpaceTradersClient) PurchaseShip(ctx context.Context, shipType, waypointSymbol string) (*schema.PurchaseShipResponse, error) {
+	return c.endpoints.PurchaseShip(ctx, shipType, waypointSymbol)
+}
+
 // Contract Operations
 
-// GetContracts retrieves all contracts available to the agent
+// GetContracts retrieves one page of contracts available to the agent
 func (c *SpaceTradersClient) GetContracts(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Contract, error) {
 	return c.endpoints.GetContracts(ctx, opts)
 }
 
+// Contracts returns an iterator over every contract available to the agent, following pagination
+func (c *SpaceTradersClient) Contracts(ctx context.Context, opts *schema.PaginationOptions) *endpoints.Iterator[schema.Contract] {
+	return c.endpoints.Contracts(ctx, opts)
+}
+
+// ListAllContracts retrieves every contract available to the agent across all pages
+func (c *SpaceTradersClient) ListAllContracts(ctx context.Context) ([]schema.Contract, error) {
+	return c.endpoints.Contracts(ctx, nil).All()
+}
+
 // GetContract retrieves information about a specific contract
 func (c *SpaceTradersClient) GetContract(ctx context.Context, contractID string) (*schema.Contract, error) {
 	return c.endpoints.GetContract(ctx, contractID)
 }
 
-// AcceptContract accepts a contract
-func (c *SpaceTradersClient) AcceptContract(ctx context.Context, contractID string) (*schema.Contract, error) {
+// AcceptContract accepts a contract and returns the updated agent and contract
+func (c *SpaceTradersClient) AcceptContract(ctx context.Context, contractID string) (*schema.AcceptContractResponse, error) {
 	return c.endpoints.AcceptContract(ctx, contractID)
 }
 
-// DeliverContract delivers cargo for a contract
-func (c *SpaceTradersClient) DeliverContract(ctx context.Context, contractID, shipSymbol, tradeSymbol string, units int) (*schema.Contract, error) {
+// DeliverContract delivers cargo for a contract and returns the updated contract and ship cargo
+func (c *SpaceTradersClient) DeliverContract(ctx context.Context, contractID, shipSymbol, tradeSymbol string, units int) (*schema.DeliverContractResponse, error) {
 	return c.endpoints.DeliverContract(ctx, contractID, shipSymbol, tradeSymbol, units)
 }
 
-// FulfillContract fulfills a contract
-func (c *SpaceTradersClient) FulfillContract(ctx context.Context, contractID string) (*schema.Contract, error) {
+// FulfillContract fulfills a contract and returns the updated agent and contract
+func (c *SpaceTradersClient) FulfillContract(ctx context.Context, contractID string) (*schema.FulfillContractResponse, error) {
 	return c.endpoints.FulfillContract(ctx, contractID)
 }
 
+// NegotiateContract negotiates a new contract using a ship docked at a faction waypoint
+func (c *SpaceTradersClient) NegotiateContract(ctx context.Context, shipSymbol string) (*schema.Contract, error) {
+	return c.endpoints.NegotiateContract(ctx, shipSymbol)
+}
+
 // System & Exploration Operations
 
-// GetSystems retrieves all systems
+// GetSystems retrieves one page of systems
 func (c *SpaceTradersClient) GetSystems(ctx context.Context, opts *schema.PaginationOptions) ([]schema.System, error) {
 	return c.endpoints.GetSystems(ctx, opts)
 }
 
+// Systems returns an iterator over every system, following pagination
+func (c *SpaceTradersClient) Systems(ctx context.Context, opts *schema.PaginationOptions) *endpoints.Iterator[schema.System] {
+	return c.endpoints.Systems(ctx, opts)
+}
+
+// ListAllSystems retrieves every system across all pages
+func (c *SpaceTradersClient) ListAllSystems(ctx context.Context) ([]schema.System, error) {
+	return c.endpoints.Systems(ctx, nil).All()
+}
+
 // GetSystem retrieves information about a specific system
 func (c *SpaceTradersClient) GetSystem(ctx context.Context, systemSymbol string) (*schema.System, error) {
 	return c.endpoints.GetSystem(ctx, systemSymbol)
 }
 
-// GetWaypoints retrieves all waypoints in a system
-func (c *SpaceTradersClient) GetWaypoints(ctx context.Context, systemSymbol string, opts *schema.PaginationOptions) ([]schema.Waypoint, error) {
+// GetWaypoints retrieves one page of the waypoints in a system, optionally filtered by type and traits
+func (c *SpaceTradersClient) GetWaypoints(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) ([]schema.Waypoint, error) {
 	return c.endpoints.GetWaypoints(ctx, systemSymbol, opts)
 }
 
+// Waypoints returns an iterator over every waypoint in a system matching the filter, following pagination
+func (c *SpaceTradersClient) Waypoints(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) *endpoints.Iterator[schema.Waypoint] {
+	return c.endpoints.Waypoints(ctx, systemSymbol, opts)
+}
+
+// ListAllWaypoints retrieves every waypoint in a system matching the filter across all pages
+func (c *SpaceTradersClient) ListAllWaypoints(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) ([]schema.Waypoint, error) {
+	return c.endpoints.Waypoints(ctx, systemSymbol, opts).All()
+}
+
 // GetWaypoint retrieves information about a specific waypoint
 func (c *SpaceTradersClient) GetWaypoint(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Waypoint, error) {
 	return c.endpoints.GetWaypoint(ctx, systemSymbol, waypointSymbol)
 }
 
+// GetJumpGate retrieves the connections of the jump gate at a waypoint
+func (c *SpaceTradersClient) GetJumpGate(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.JumpGate, error) {
+	return c.endpoints.GetJumpGate(ctx, systemSymbol, waypointSymbol)
+}
+
+// GetConstruction retrieves the construction progress of a waypoint
+func (c *SpaceTradersClient) GetConstruction(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Construction, error) {
+	return c.endpoints.GetConstruction(ctx, systemSymbol, waypointSymbol)
+}
+
+// SupplyConstruction delivers materials from a ship's cargo to a construction site
+func (c *SpaceTradersClient) SupplyConstruction(ctx context.Context, systemSymbol, waypointSymbol string, req *schema.SupplyConstructionRequest) (*schema.SupplyConstructionResponse, error) {
+	return c.endpoints.SupplyConstruction(ctx, systemSymbol, waypointSymbol, req)
+}
+
 // Mining & Survey Operations
 
-// CreateSurvey creates a survey at the current waypoint
-func (c *SpaceTradersClient) CreateSurvey(ctx context.Context, shipSymbol string) (*schema.Survey, error) {
+// CreateSurvey surveys the current waypoint and returns the surveys and ship cooldown
+func (c *SpaceTradersClient) CreateSurvey(ctx context.Context, shipSymbol string) (*schema.CreateSurveyResponse, error) {
 	return c.endpoints.CreateSurvey(ctx, shipSymbol)
 }
 
-// ExtractResources extracts resources at the current waypoint
-func (c *SpaceTradersClient) ExtractResources(ctx context.Context, shipSymbol string, survey *schema.Survey) (*schema.Extraction, error) {
+// ExtractResources extracts resources at the current waypoint, targeting the survey if one is given.
+// Cooldown and unusable surveys are reported as *transport.CooldownError,
+// *transport.SurveyExpiredError and *transport.SurveyExhaustedError.
+func (c *SpaceTradersClient) ExtractResources(ctx context.Context, shipSymbol string, survey *schema.Survey) (*schema.ExtractResourcesResponse, error) {
 	return c.endpoints.ExtractResources(ctx, shipSymbol, survey)
 }
 
+// SiphonResources siphons gases at the gas giant the ship is orbiting.
+// A ship still on cooldown is reported as *transport.CooldownError.
+func (c *SpaceTradersClient) SiphonResources(ctx context.Context, shipSymbol string) (*schema.SiphonResourcesResponse, error) {
+	return c.endpoints.SiphonResources(ctx, shipSymbol)
+}
+
+// Scanning & Charting Operations
+
+// CreateChart charts the waypoint the ship is at, submitting it to the universe
+func (c *SpaceTradersClient) CreateChart(ctx context.Context, shipSymbol string) (*schema.CreateChartResponse, error) {
+	return c.endpoints.CreateChart(ctx, shipSymbol)
+}
+
+// ScanSystems scans for systems within range of the ship's sensors
+func (c *SpaceTradersClient) ScanSystems(ctx context.Context, shipSymbol string) (*schema.ScanSystemsResponse, error) {
+	return c.endpoints.ScanSystems(ctx, shipSymbol)
+}
+
+// ScanWaypoints scans for waypoints within range of the ship's sensors
+func (c *SpaceTradersClient) ScanWaypoints(ctx context.Context, shipSymbol string) (*schema.ScanWaypointsResponse, error) {
+	return c.endpoints.ScanWaypoints(ctx, shipSymbol)
+}
+
+// ScanShips scans for other ships within range of the ship's sensors
+func (c *SpaceTradersClient) ScanShips(ctx context.Context, shipSymbol string) (*schema.ScanShipsResponse, error) {
+	return c.endpoints.ScanShips(ctx, shipSymbol)
+}
+
 // Faction Operations
 
-// GetFactions retrieves all factions
+// GetFactions retrieves one page of factions
 func (c *SpaceTradersClient) GetFactions(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Faction, error) {
 	return c.endpoints.GetFactions(ctx, opts)
 }
 
+// Factions returns an iterator over every faction, following pagination
+func (c *SpaceTradersClient) Factions(ctx context.Context, opts *schema.PaginationOptions) *endpoints.Iterator[schema.Faction] {
+	return c.endpoints.Factions(ctx, opts)
+}
+
+// ListAllFactions retrieves every faction across all pages
+func (c *SpaceTradersClient) ListAllFactions(ctx context.Context) ([]schema.Faction, error) {
+	return c.endpoints.Factions(ctx, nil).All()
+}
+
 // GetFaction retrieves information about a specific faction
 func (c *SpaceTradersClient) GetFaction(ctx context.Context, factionSymbol string) (*schema.Faction, error) {
 	return c.endpoints.GetFaction(ctx, factionSymbol)
 }
 
+// Response Metadata
+
+// Result is an API result together with the metadata of the response that produced it
+type Result[T any] struct {
+	Data T
+	transport.ResponseInfo
+}
+
+// WithResponse runs call and returns its result along with the response
+// status, headers, request ID, latency, rate limit state and pagination
+// metadata. It works with any client method:
+//
+//	res, err := client.WithResponse(ctx, func(ctx context.Context) ([]schema.Ship, error) {
+//		return c.GetFleet(ctx, nil)
+//	})
+//	log.Printf("%d of %d ships, %d requests left", len(res.Data), res.Meta.Total, res.RateLimit.Remaining)
+//
+// The result is returned even when call fails, so the metadata of error
+// responses can be inspected. If call makes several requests, the metadata
+// describes the last one; if it makes none (e.g. a cached agent), it is zero.
+func WithResponse[T any](ctx context.Context, call func(ctx context.Context) (T, error)) (*Result[T], error) {
+	result := &Result[T]{}
+	data, err := call(transport.WithResponseInfo(ctx, &result.ResponseInfo))
+	result.Data = data
+	return result, err
+}
+
 // Utility Methods
 
 // ValidateToken validates the current authentication token
@@ -289,18 +617,42 @@ func (c *SpaceTradersClient) GetTokenInfo(ctx context.Context) *auth.TokenInfo {
 }
 
 // GetRateLimiterState returns the current state of the rate limiter
-func (c *SpaceTradersClient) GetRateLimiterState() interface{} {
-	// This would return the actual rate limiter state
-	// For now, return a placeholder
-	return map[string]interface{}{
-		"tokens_available": true,
-		"next_refill":      time.Now().Add(time.Second),
-	}
+func (c *SpaceTradersClient) GetRateLimiterState() ratelimit.BucketState {
+	return c.httpClient.GetRateLimiterState()
+}
+
+// Reserve claims n requests from the rate limiter for a sequence of calls,
+// such as dock, refuel, sell and orbit, and reports when they may all be
+// sent. Make the calls with transport.WithReservation to spend the reserved
+// requests, and cancel the reservation if the sequence is abandoned.
+func (c *SpaceTradersClient) Reserve(n int) (*transport.Reservation, error) {
+	return c.httpClient.Reserve(n)
+}
+
+// SetShipWeight sets how many consecutive requests a ship may send per
+// round-robin turn when FairShare is enabled; the default is 1
+func (c *SpaceTradersClient) SetShipWeight(shipSymbol string, weight int) {
+	c.httpClient.SetRateLimitWeight(shipSymbol, weight)
+}
+
+// GetShipWaitStats returns how long each ship's requests waited for the
+// rate limiter, or nil when FairShare is disabled
+func (c *SpaceTradersClient) GetShipWaitStats() map[string]transport.KeyStats {
+	return c.httpClient.GetRateLimitKeyStats()
 }
 
 // Close closes the client and cleans up resources
 func (c *SpaceTradersClient) Close() error {
-	// In a real implementation, this might close HTTP connections, etc.
 	c.auth.ClearAuth()
-	return nil
+	c.httpClient.CloseIdleConnections()
+
+	var firstErr error
+	for _, closer := range c.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	c.closers = nil
+
+	return firstErr
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/url"
 	"strconv"
 )
 
@@ -21,32 +22,113 @@ func NewEndpointManager(httpClient *transport.HTTPClient) *EndpointManager {
 	}
 }
 
-// Ship Operations
+// Server & Agent Operations
 
-// GetFleet retrieves all ships owned by the agent
-func (e *EndpointManager) GetFleet(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Ship, error) {
+// GetServerStatus retrieves the server status, leaderboards and reset schedule
+func (e *EndpointManager) GetServerStatus(ctx context.Context) (*schema.ServerStatus, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The status endpoint is not wrapped in a data envelope
+	var status schema.ServerStatus
+	if err := json.Unmarshal(resp.Body, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server status response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// ListAgents retrieves one page of the public details of all agents
+func (e *EndpointManager) ListAgents(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Agent, error) {
+	items, _, err := e.listAgentsPage(ctx, opts)
+	return items, err
+}
+
+// Agents returns an iterator over the public details of every agent
+func (e *EndpointManager) Agents(ctx context.Context, opts *schema.PaginationOptions) *Iterator[schema.Agent] {
+	return NewIterator(ctx, opts, e.listAgentsPage)
+}
+
+// listAgentsPage fetches a single page of agents along with its pagination metadata
+func (e *EndpointManager) listAgentsPage(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Agent, *schema.Meta, error) {
 	req := &transport.Request{
 		Method:      "GET",
-		Path:        "/my/ships",
+		Path:        "/agents",
 		QueryParams: buildPaginationParams(opts),
 	}
 
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.Agent]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal agents response: %w", err)
+	}
+
+	return apiResp.Data, apiResp.Meta, nil
+}
+
+// GetPublicAgent retrieves the public details of an agent
+func (e *EndpointManager) GetPublicAgent(ctx context.Context, agentSymbol string) (*schema.Agent, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/agents/" + agentSymbol,
+	}
+
 	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.Agent]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal fleet response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal agent response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Ship Operations
+
+// GetFleet retrieves one page of ships owned by the agent
+func (e *EndpointManager) GetFleet(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Ship, error) {
+	items, _, err := e.getFleetPage(ctx, opts)
+	return items, err
+}
+
+// Ships returns an iterator over every ship owned by the agent
+func (e *EndpointManager) Ships(ctx context.Context, opts *schema.PaginationOptions) *Iterator[schema.Ship] {
+	return NewIterator(ctx, opts, e.getFleetPage)
+}
+
+// getFleetPage fetches a single page of the fleet along with its pagination metadata
+func (e *EndpointManager) getFleetPage(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Ship, *schema.Meta, error) {
+	req := &transport.Request{
+		Method:      "GET",
+		Path:        "/my/ships",
+		QueryParams: buildPaginationParams(opts),
 	}
 
-	ships, err := parseShipsData(apiResp.Data)
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ships data: %w", err)
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.Ship]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal fleet response: %w", err)
 	}
 
-	return ships, nil
+	return apiResp.Data, apiResp.Meta, nil
 }
 
 // GetShip retrieves information about a specific ship
@@ -61,17 +143,12 @@ func (e *EndpointManager) GetShip(ctx context.Context, shipSymbol string) (*sche
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.Ship]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal ship response: %w", err)
 	}
 
-	ship, err := parseShipData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse ship data: %w", err)
-	}
-
-	return ship, nil
+	return &apiResp.Data, nil
 }
 
 // OrbitShip puts a ship into orbit
@@ -86,21 +163,14 @@ func (e *EndpointManager) OrbitShip(ctx context.Context, shipSymbol string) (*sc
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.ShipNavResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal orbit response: %w", err)
 	}
 
-	// Extract nav data from response
-	navData, err := parseNavData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse nav data: %w", err)
-	}
-
-	// Return ship with updated nav (simplified for this implementation)
 	ship := &schema.Ship{
 		Symbol: shipSymbol,
-		Nav:    *navData,
+		Nav:    apiResp.Data.Nav,
 	}
 
 	return ship, nil
@@ -118,19 +188,14 @@ func (e *EndpointManager) DockShip(ctx context.Context, shipSymbol string) (*sch
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.ShipNavResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal dock response: %w", err)
 	}
 
-	navData, err := parseNavData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse nav data: %w", err)
-	}
-
 	ship := &schema.Ship{
 		Symbol: shipSymbol,
-		Nav:    *navData,
+		Nav:    apiResp.Data.Nav,
 	}
 
 	return ship, nil
@@ -148,21 +213,16 @@ func (e *EndpointManager) RefuelShip(ctx context.Context, shipSymbol string) (*s
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.Transaction]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal refuel response: %w", err)
 	}
 
-	transaction, err := parseTransactionData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse transaction data: %w", err)
-	}
-
-	return transaction, nil
+	return &apiResp.Data, nil
 }
 
-// NavigateShip navigates a ship to a waypoint
-func (e *EndpointManager) NavigateShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.Navigation, error) {
+// NavigateShip navigates a ship to a waypoint and returns its updated nav, fuel and condition events
+func (e *EndpointManager) NavigateShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.NavigateResult, error) {
 	req := &transport.Request{
 		Method: "POST",
 		Path:   "/my/ships/" + shipSymbol + "/navigate",
@@ -176,24 +236,43 @@ func (e *EndpointManager) NavigateShip(ctx context.Context, shipSymbol, waypoint
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.NavigateResult]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal navigate response: %w", err)
 	}
 
-	nav, err := parseNavData(apiResp.Data)
+	return &apiResp.Data, nil
+}
+
+// SetFlightMode changes a ship's flight mode (CRUISE, DRIFT, BURN or STEALTH)
+func (e *EndpointManager) SetFlightMode(ctx context.Context, shipSymbol, flightMode string) (*schema.NavigateResult, error) {
+	req := &transport.Request{
+		Method: "PATCH",
+		Path:   "/my/ships/" + shipSymbol + "/nav",
+		Body: schema.SetFlightModeRequest{
+			FlightMode: flightMode,
+		},
+		Idempotent: true, // Setting the same flight mode twice has no further effect
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse navigation data: %w", err)
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.NavigateResult]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal flight mode response: %w", err)
 	}
 
-	return nav, nil
+	return &apiResp.Data, nil
 }
 
-// GetShipNav gets the navigation information for a ship
-func (e *EndpointManager) GetShipNav(ctx context.Context, shipSymbol string) (*schema.Navigation, error) {
+// GetShipCooldown gets the reactor cooldown of a ship. It returns nil if the ship has no active cooldown.
+func (e *EndpointManager) GetShipCooldown(ctx context.Context, shipSymbol string) (*schema.Cooldown, error) {
 	req := &transport.Request{
 		Method: "GET",
-		Path:   "/my/ships/" + shipSymbol + "/nav",
+		Path:   "/my/ships/" + shipSymbol + "/cooldown",
 	}
 
 	resp, err := e.httpClient.Do(ctx, req)
@@ -201,24 +280,27 @@ func (e *EndpointManager) GetShipNav(ctx context.Context, shipSymbol string) (*s
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
-	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal nav response: %w", err)
+	// The API responds with 204 No Content when there is no cooldown
+	if resp.IsNoContent() {
+		return nil, nil
 	}
 
-	nav, err := parseNavData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse nav data: %w", err)
+	var apiResp schema.APIResponse[schema.Cooldown]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cooldown response: %w", err)
 	}
 
-	return nav, nil
+	return &apiResp.Data, nil
 }
 
-// GetShipCargo gets the cargo information for a ship
-func (e *EndpointManager) GetShipCargo(ctx context.Context, shipSymbol string) (*schema.Cargo, error) {
+// JumpShip jumps a ship from its current jump gate to a connected jump gate
+func (e *EndpointManager) JumpShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.JumpShipResponse, error) {
 	req := &transport.Request{
-		Method: "GET",
-		Path:   "/my/ships/" + shipSymbol + "/cargo",
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/jump",
+		Body: schema.JumpShipRequest{
+			WaypointSymbol: waypointSymbol,
+		},
 	}
 
 	resp, err := e.httpClient.Do(ctx, req)
@@ -226,26 +308,42 @@ func (e *EndpointManager) GetShipCargo(ctx context.Context, shipSymbol string) (
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.JumpShipResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal cargo response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal jump response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// WarpShip warps a ship to a waypoint in another system and returns its updated nav, fuel and condition events
+func (e *EndpointManager) WarpShip(ctx context.Context, shipSymbol, waypointSymbol string) (*schema.NavigateResult, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/warp",
+		Body: schema.WarpShipRequest{
+			WaypointSymbol: waypointSymbol,
+		},
 	}
 
-	cargo, err := parseCargoData(apiResp.Data)
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse cargo data: %w", err)
+		return nil, err
 	}
 
-	return cargo, nil
-}
+	var apiResp schema.APIResponse[schema.NavigateResult]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal warp response: %w", err)
+	}
 
-// Market Operations
+	return &apiResp.Data, nil
+}
 
-// GetMarket retrieves market information for a waypoint
-func (e *EndpointManager) GetMarket(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Market, error) {
+// GetShipNav gets the navigation information for a ship
+func (e *EndpointManager) GetShipNav(ctx context.Context, shipSymbol string) (*schema.Navigation, error) {
 	req := &transport.Request{
 		Method: "GET",
-		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/market",
+		Path:   "/my/ships/" + shipSymbol + "/nav",
 	}
 
 	resp, err := e.httpClient.Do(ctx, req)
@@ -253,24 +351,39 @@ func (e *EndpointManager) GetMarket(ctx context.Context, systemSymbol, waypointS
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.Navigation]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal market response: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal nav response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetShipCargo gets the cargo information for a ship
+func (e *EndpointManager) GetShipCargo(ctx context.Context, shipSymbol string) (*schema.Cargo, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/my/ships/" + shipSymbol + "/cargo",
 	}
 
-	market, err := parseMarketData(apiResp.Data)
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse market data: %w", err)
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Cargo]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cargo response: %w", err)
 	}
 
-	return market, nil
+	return &apiResp.Data, nil
 }
 
-// PurchaseCargo purchases cargo from a market
-func (e *EndpointManager) PurchaseCargo(ctx context.Context, shipSymbol string, req *schema.PurchaseCargoRequest) (*schema.Transaction, error) {
+// JettisonCargo jettisons cargo from a ship and returns its updated cargo
+func (e *EndpointManager) JettisonCargo(ctx context.Context, shipSymbol string, req *schema.JettisonCargoRequest) (*schema.Cargo, error) {
 	httpReq := &transport.Request{
 		Method: "POST",
-		Path:   "/my/ships/" + shipSymbol + "/purchase",
+		Path:   "/my/ships/" + shipSymbol + "/jettison",
 		Body:   req,
 	}
 
@@ -279,24 +392,19 @@ func (e *EndpointManager) PurchaseCargo(ctx context.Context, shipSymbol string,
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.JettisonCargoResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal purchase response: %w", err)
-	}
-
-	transaction, err := parseTransactionData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse transaction data: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal jettison response: %w", err)
 	}
 
-	return transaction, nil
+	return &apiResp.Data.Cargo, nil
 }
 
-// SellCargo sells cargo to a market
-func (e *EndpointManager) SellCargo(ctx context.Context, shipSymbol string, req *schema.SellCargoRequest) (*schema.Transaction, error) {
+// TransferCargo transfers cargo from one ship to another at the same waypoint
+func (e *EndpointManager) TransferCargo(ctx context.Context, shipSymbol string, req *schema.TransferCargoRequest) (*schema.TransferCargoResponse, error) {
 	httpReq := &transport.Request{
 		Method: "POST",
-		Path:   "/my/ships/" + shipSymbol + "/sell",
+		Path:   "/my/ships/" + shipSymbol + "/transfer",
 		Body:   req,
 	}
 
@@ -305,178 +413,842 @@ func (e *EndpointManager) SellCargo(ctx context.Context, shipSymbol string, req
 		return nil, err
 	}
 
-	var apiResp schema.APIResponse
+	var apiResp schema.APIResponse[schema.TransferCargoResponse]
 	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal sell response: %w", err)
-	}
-
-	transaction, err := parseTransactionData(apiResp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse transaction data: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal transfer response: %w", err)
 	}
 
-	return transaction, nil
+	return &apiResp.Data, nil
 }
 
-// Contract Operations (simplified implementations)
-
-func (e *EndpointManager) GetContracts(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Contract, error) {
-	// Implementation similar to GetFleet but for contracts
-	return nil, fmt.Errorf("not implemented")
-}
-
-func (e *EndpointManager) GetContract(ctx context.Context, contractID string) (*schema.Contract, error) {
-	// Implementation similar to GetShip but for contracts
-	return nil, fmt.Errorf("not implemented")
-}
+// RefineCargo refines raw goods in a ship's cargo into the requested good
+func (e *EndpointManager) RefineCargo(ctx context.Context, shipSymbol string, req *schema.RefineCargoRequest) (*schema.RefineCargoResponse, error) {
+	httpReq := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/refine",
+		Body:   req,
+	}
 
-func (e *EndpointManager) AcceptContract(ctx context.Context, contractID string) (*schema.Contract, error) {
-	// Implementation similar to OrbitShip but for contracts
-	return nil, fmt.Errorf("not implemented")
-}
+	resp, err := e.httpClient.Do(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
 
-func (e *EndpointManager) DeliverContract(ctx context.Context, contractID, shipSymbol, tradeSymbol string, units int) (*schema.Contract, error) {
-	// Implementation for contract delivery
-	return nil, fmt.Errorf("not implemented")
-}
+	var apiResp schema.APIResponse[schema.RefineCargoResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal refine response: %w", err)
+	}
 
-func (e *EndpointManager) FulfillContract(ctx context.Context, contractID string) (*schema.Contract, error) {
-	// Implementation for contract fulfillment
-	return nil, fmt.Errorf("not implemented")
+	return &apiResp.Data, nil
 }
 
-// System Operations (simplified implementations)
+// Ship Outfitting Operations
 
-func (e *EndpointManager) GetSystems(ctx context.Context, opts *schema.PaginationOptions) ([]schema.System, error) {
-	return nil, fmt.Errorf("not implemented")
+// InstallMount installs a mount from the ship's cargo at a shipyard
+func (e *EndpointManager) InstallMount(ctx context.Context, shipSymbol, mountSymbol string) (*schema.ShipMountsResponse, error) {
+	return e.changeMounts(ctx, shipSymbol, "install", mountSymbol)
 }
 
-func (e *EndpointManager) GetSystem(ctx context.Context, systemSymbol string) (*schema.System, error) {
-	return nil, fmt.Errorf("not implemented")
+// RemoveMount removes a mount from a ship at a shipyard, placing it in the ship's cargo
+func (e *EndpointManager) RemoveMount(ctx context.Context, shipSymbol, mountSymbol string) (*schema.ShipMountsResponse, error) {
+	return e.changeMounts(ctx, shipSymbol, "remove", mountSymbol)
 }
 
-func (e *EndpointManager) GetWaypoints(ctx context.Context, systemSymbol string, opts *schema.PaginationOptions) ([]schema.Waypoint, error) {
-	return nil, fmt.Errorf("not implemented")
+// InstallModule installs a module from the ship's cargo at a shipyard
+func (e *EndpointManager) InstallModule(ctx context.Context, shipSymbol, moduleSymbol string) (*schema.ShipModulesResponse, error) {
+	return e.changeModules(ctx, shipSymbol, "install", moduleSymbol)
 }
 
-func (e *EndpointManager) GetWaypoint(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Waypoint, error) {
-	return nil, fmt.Errorf("not implemented")
+// RemoveModule removes a module from a ship at a shipyard, placing it in the ship's cargo
+func (e *EndpointManager) RemoveModule(ctx context.Context, shipSymbol, moduleSymbol string) (*schema.ShipModulesResponse, error) {
+	return e.changeModules(ctx, shipSymbol, "remove", moduleSymbol)
 }
 
-func (e *EndpointManager) CreateSurvey(ctx context.Context, shipSymbol string) (*schema.Survey, error) {
-	return nil, fmt.Errorf("not implemented")
-}
+// GetRepairQuote retrieves the cost of repairing a ship at its current shipyard
+func (e *EndpointManager) GetRepairQuote(ctx context.Context, shipSymbol string) (*schema.RepairTransaction, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/my/ships/" + shipSymbol + "/repair",
+	}
 
-func (e *EndpointManager) ExtractResources(ctx context.Context, shipSymbol string, survey *schema.Survey) (*schema.Extraction, error) {
-	return nil, fmt.Errorf("not implemented")
-}
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
-func (e *EndpointManager) GetFactions(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Faction, error) {
-	return nil, fmt.Errorf("not implemented")
-}
+	var apiResp schema.APIResponse[schema.RepairQuoteResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repair quote response: %w", err)
+	}
 
-func (e *EndpointManager) GetFaction(ctx context.Context, factionSymbol string) (*schema.Faction, error) {
-	return nil, fmt.Errorf("not implemented")
+	return &apiResp.Data.Transaction, nil
 }
 
-// Helper functions for parsing API responses
-
-func buildPaginationParams(opts *schema.PaginationOptions) map[string]string {
-	if opts == nil {
-		return nil
+// RepairShip repairs a ship's frame, reactor and engine at its current shipyard
+func (e *EndpointManager) RepairShip(ctx context.Context, shipSymbol string) (*schema.RepairShipResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/repair",
 	}
 
-	params := make(map[string]string)
-	if opts.Page != nil {
-		params["page"] = strconv.Itoa(*opts.Page)
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-	if opts.Limit != nil {
-		params["limit"] = strconv.Itoa(*opts.Limit)
+
+	var apiResp schema.APIResponse[schema.RepairShipResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repair response: %w", err)
 	}
 
-	return params
+	return &apiResp.Data, nil
 }
 
-func parseShipsData(data interface{}) ([]schema.Ship, error) {
-	jsonData, err := json.Marshal(data)
+// GetScrapQuote retrieves the value of scrapping a ship at its current shipyard
+func (e *EndpointManager) GetScrapQuote(ctx context.Context, shipSymbol string) (*schema.ScrapTransaction, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/my/ships/" + shipSymbol + "/scrap",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var ships []schema.Ship
-	if err := json.Unmarshal(jsonData, &ships); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.ScrapQuoteResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scrap quote response: %w", err)
 	}
 
-	return ships, nil
+	return &apiResp.Data.Transaction, nil
 }
 
-func parseShipData(data interface{}) (*schema.Ship, error) {
-	jsonData, err := json.Marshal(data)
+// ScrapShip scraps a ship at its current shipyard. The ship is removed from the fleet.
+func (e *EndpointManager) ScrapShip(ctx context.Context, shipSymbol string) (*schema.ScrapShipResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/scrap",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var ship schema.Ship
-	if err := json.Unmarshal(jsonData, &ship); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.ScrapShipResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scrap response: %w", err)
 	}
 
-	return &ship, nil
+	return &apiResp.Data, nil
 }
 
-func parseNavData(data interface{}) (*schema.Navigation, error) {
-	jsonData, err := json.Marshal(data)
+// changeMounts installs or removes a ship mount
+func (e *EndpointManager) changeMounts(ctx context.Context, shipSymbol, action, mountSymbol string) (*schema.ShipMountsResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/mounts/" + action,
+		Body: schema.ShipMountRequest{
+			Symbol: mountSymbol,
+		},
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var nav schema.Navigation
-	if err := json.Unmarshal(jsonData, &nav); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.ShipMountsResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mount %s response: %w", action, err)
 	}
 
-	return &nav, nil
+	return &apiResp.Data, nil
 }
 
-func parseCargoData(data interface{}) (*schema.Cargo, error) {
-	jsonData, err := json.Marshal(data)
+// changeModules installs or removes a ship module
+func (e *EndpointManager) changeModules(ctx context.Context, shipSymbol, action, moduleSymbol string) (*schema.ShipModulesResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/modules/" + action,
+		Body: schema.ShipModuleRequest{
+			Symbol: moduleSymbol,
+		},
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var cargo schema.Cargo
-	if err := json.Unmarshal(jsonData, &cargo); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.ShipModulesResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal module %s response: %w", action, err)
 	}
 
-	return &cargo, nil
+	return &apiResp.Data, nil
 }
 
-func parseMarketData(data interface{}) (*schema.Market, error) {
-	jsonData, err := json.Marshal(data)
+// Market Operations
+
+// GetMarket retrieves market information for a waypoint
+func (e *EndpointManager) GetMarket(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Market, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/market",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var market schema.Market
-	if err := json.Unmarshal(jsonData, &market); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.Market]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal market response: %w", err)
 	}
 
-	return &market, nil
+	return &apiResp.Data, nil
 }
 
-func parseTransactionData(data interface{}) (*schema.Transaction, error) {
-	jsonData, err := json.Marshal(data)
+// PurchaseCargo purchases cargo from a market
+func (e *EndpointManager) PurchaseCargo(ctx context.Context, shipSymbol string, req *schema.PurchaseCargoRequest) (*schema.Transaction, error) {
+	httpReq := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/purchase",
+		Body:   req,
+	}
+
+	resp, err := e.httpClient.Do(ctx, httpReq)
 	if err != nil {
 		return nil, err
 	}
 
-	var transaction schema.Transaction
-	if err := json.Unmarshal(jsonData, &transaction); err != nil {
-		return nil, err
+	var apiResp schema.APIResponse[schema.Transaction]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal purchase response: %w", err)
 	}
 
-	return &transaction, nil
+	return &apiResp.Data, nil
+}
+
+// SellCargo sells cargo to a market
+func (e *EndpointManager) SellCargo(ctx context.Context, shipSymbol string, req *schema.SellCargoRequest) (*schema.Transaction, error) {
+	httpReq := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/sell",
+		Body:   req,
+	}
+
+	resp, err := e.httpClient.Do(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Transaction]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sell response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Shipyard Operations
+
+// GetShipyard retrieves shipyard information for a waypoint
+func (e *EndpointManager) GetShipyard(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Shipyard, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/shipyard",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Shipyard]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shipyard response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// PurchaseShip purchases a ship of the given type from the shipyard at a waypoint
+func (e *EndpointManager) PurchaseShip(ctx context.Context, shipType, waypointSymbol string) (*schema.PurchaseShipResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships",
+		Body: schema.PurchaseShipRequest{
+			ShipType:       shipType,
+			WaypointSymbol: waypointSymbol,
+		},
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.PurchaseShipResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal purchase ship response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Contract Operations
+
+// GetContracts retrieves one page of contracts available to the agent
+func (e *EndpointManager) GetContracts(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Contract, error) {
+	items, _, err := e.getContractsPage(ctx, opts)
+	return items, err
+}
+
+// Contracts returns an iterator over every contract available to the agent
+func (e *EndpointManager) Contracts(ctx context.Context, opts *schema.PaginationOptions) *Iterator[schema.Contract] {
+	return NewIterator(ctx, opts, e.getContractsPage)
+}
+
+// getContractsPage fetches a single page of contracts along with its pagination metadata
+func (e *EndpointManager) getContractsPage(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Contract, *schema.Meta, error) {
+	req := &transport.Request{
+		Method:      "GET",
+		Path:        "/my/contracts",
+		QueryParams: buildPaginationParams(opts),
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.Contract]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal contracts response: %w", err)
+	}
+
+	return apiResp.Data, apiResp.Meta, nil
+}
+
+// GetContract retrieves information about a specific contract
+func (e *EndpointManager) GetContract(ctx context.Context, contractID string) (*schema.Contract, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/my/contracts/" + contractID,
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Contract]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// AcceptContract accepts a contract and returns the updated agent and contract
+func (e *EndpointManager) AcceptContract(ctx context.Context, contractID string) (*schema.AcceptContractResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/contracts/" + contractID + "/accept",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.AcceptContractResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal accept contract response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// DeliverContract delivers cargo from a ship for a contract and returns the
+// updated contract and ship cargo
+func (e *EndpointManager) DeliverContract(ctx context.Context, contractID, shipSymbol, tradeSymbol string, units int) (*schema.DeliverContractResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/contracts/" + contractID + "/deliver",
+		Body: schema.DeliverContractRequest{
+			ShipSymbol:  shipSymbol,
+			TradeSymbol: tradeSymbol,
+			Units:       units,
+		},
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.DeliverContractResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deliver contract response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// FulfillContract fulfills a contract and returns the updated agent and contract
+func (e *EndpointManager) FulfillContract(ctx context.Context, contractID string) (*schema.FulfillContractResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/contracts/" + contractID + "/fulfill",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.FulfillContractResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fulfill contract response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// NegotiateContract negotiates a new contract with the faction at the ship's current waypoint
+func (e *EndpointManager) NegotiateContract(ctx context.Context, shipSymbol string) (*schema.Contract, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/negotiate/contract",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.NegotiateContractResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal negotiate contract response: %w", err)
+	}
+
+	return &apiResp.Data.Contract, nil
+}
+
+// System Operations
+
+// GetSystems retrieves one page of systems
+func (e *EndpointManager) GetSystems(ctx context.Context, opts *schema.PaginationOptions) ([]schema.System, error) {
+	items, _, err := e.getSystemsPage(ctx, opts)
+	return items, err
+}
+
+// Systems returns an iterator over every system in the universe
+func (e *EndpointManager) Systems(ctx context.Context, opts *schema.PaginationOptions) *Iterator[schema.System] {
+	return NewIterator(ctx, opts, e.getSystemsPage)
+}
+
+// getSystemsPage fetches a single page of systems along with its pagination metadata
+func (e *EndpointManager) getSystemsPage(ctx context.Context, opts *schema.PaginationOptions) ([]schema.System, *schema.Meta, error) {
+	req := &transport.Request{
+		Method:      "GET",
+		Path:        "/systems",
+		QueryParams: buildPaginationParams(opts),
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.System]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal systems response: %w", err)
+	}
+
+	return apiResp.Data, apiResp.Meta, nil
+}
+
+// GetSystem retrieves information about a specific system
+func (e *EndpointManager) GetSystem(ctx context.Context, systemSymbol string) (*schema.System, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol,
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.System]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal system response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetWaypoints retrieves one page of the waypoints in a system, optionally filtered by type and traits
+func (e *EndpointManager) GetWaypoints(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) ([]schema.Waypoint, error) {
+	items, _, err := e.getWaypointsPage(ctx, systemSymbol, opts)
+	return items, err
+}
+
+// Waypoints returns an iterator over every waypoint in a system matching the filter
+func (e *EndpointManager) Waypoints(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) *Iterator[schema.Waypoint] {
+	var filter schema.WaypointFilterOptions
+	if opts != nil {
+		filter = *opts
+	}
+
+	return NewIterator(ctx, &filter.PaginationOptions, func(ctx context.Context, page *schema.PaginationOptions) ([]schema.Waypoint, *schema.Meta, error) {
+		pageFilter := filter
+		pageFilter.PaginationOptions = *page
+		return e.getWaypointsPage(ctx, systemSymbol, &pageFilter)
+	})
+}
+
+// getWaypointsPage fetches a single page of waypoints along with its pagination metadata
+func (e *EndpointManager) getWaypointsPage(ctx context.Context, systemSymbol string, opts *schema.WaypointFilterOptions) ([]schema.Waypoint, *schema.Meta, error) {
+	req := &transport.Request{
+		Method:      "GET",
+		Path:        "/systems/" + systemSymbol + "/waypoints",
+		QueryParams: buildWaypointFilterParams(opts),
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.Waypoint]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal waypoints response: %w", err)
+	}
+
+	return apiResp.Data, apiResp.Meta, nil
+}
+
+// GetWaypoint retrieves information about a specific waypoint
+func (e *EndpointManager) GetWaypoint(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Waypoint, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol,
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Waypoint]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal waypoint response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetJumpGate retrieves the connections of the jump gate at a waypoint
+func (e *EndpointManager) GetJumpGate(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.JumpGate, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/jump-gate",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.JumpGate]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jump gate response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// GetConstruction retrieves the construction progress of a waypoint
+func (e *EndpointManager) GetConstruction(ctx context.Context, systemSymbol, waypointSymbol string) (*schema.Construction, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/construction",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Construction]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal construction response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// SupplyConstruction delivers materials from a ship's cargo to a construction site
+func (e *EndpointManager) SupplyConstruction(ctx context.Context, systemSymbol, waypointSymbol string, req *schema.SupplyConstructionRequest) (*schema.SupplyConstructionResponse, error) {
+	httpReq := &transport.Request{
+		Method: "POST",
+		Path:   "/systems/" + systemSymbol + "/waypoints/" + waypointSymbol + "/construction/supply",
+		Body:   req,
+	}
+
+	resp, err := e.httpClient.Do(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.SupplyConstructionResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal supply construction response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Mining & Survey Operations
+
+// CreateSurvey surveys the waypoint the ship is orbiting for resource deposits
+func (e *EndpointManager) CreateSurvey(ctx context.Context, shipSymbol string) (*schema.CreateSurveyResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/survey",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.CreateSurveyResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal survey response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ExtractResources extracts resources at the current waypoint. If survey is
+// non-nil the extraction targets the surveyed deposits.
+func (e *EndpointManager) ExtractResources(ctx context.Context, shipSymbol string, survey *schema.Survey) (*schema.ExtractResourcesResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/extract",
+	}
+	if survey != nil {
+		req.Path += "/survey"
+		req.Body = survey
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.ExtractResourcesResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal extraction response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// SiphonResources siphons gases from the gas giant the ship is orbiting
+func (e *EndpointManager) SiphonResources(ctx context.Context, shipSymbol string) (*schema.SiphonResourcesResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/siphon",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.SiphonResourcesResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal siphon response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Scanning & Charting Operations
+
+// CreateChart charts the waypoint the ship is at, submitting it to the universe
+func (e *EndpointManager) CreateChart(ctx context.Context, shipSymbol string) (*schema.CreateChartResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/chart",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.CreateChartResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chart response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ScanSystems scans for systems within range of the ship's sensors
+func (e *EndpointManager) ScanSystems(ctx context.Context, shipSymbol string) (*schema.ScanSystemsResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/scan/systems",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.ScanSystemsResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal system scan response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ScanWaypoints scans for waypoints within range of the ship's sensors
+func (e *EndpointManager) ScanWaypoints(ctx context.Context, shipSymbol string) (*schema.ScanWaypointsResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/scan/waypoints",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.ScanWaypointsResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal waypoint scan response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// ScanShips scans for other ships within range of the ship's sensors
+func (e *EndpointManager) ScanShips(ctx context.Context, shipSymbol string) (*schema.ScanShipsResponse, error) {
+	req := &transport.Request{
+		Method: "POST",
+		Path:   "/my/ships/" + shipSymbol + "/scan/ships",
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.ScanShipsResponse]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ship scan response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Faction Operations
+
+// GetFactions retrieves one page of factions
+func (e *EndpointManager) GetFactions(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Faction, error) {
+	items, _, err := e.getFactionsPage(ctx, opts)
+	return items, err
+}
+
+// Factions returns an iterator over every faction
+func (e *EndpointManager) Factions(ctx context.Context, opts *schema.PaginationOptions) *Iterator[schema.Faction] {
+	return NewIterator(ctx, opts, e.getFactionsPage)
+}
+
+// getFactionsPage fetches a single page of factions along with its pagination metadata
+func (e *EndpointManager) getFactionsPage(ctx context.Context, opts *schema.PaginationOptions) ([]schema.Faction, *schema.Meta, error) {
+	req := &transport.Request{
+		Method:      "GET",
+		Path:        "/factions",
+		QueryParams: buildPaginationParams(opts),
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResp schema.APIResponse[[]schema.Faction]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal factions response: %w", err)
+	}
+
+	return apiResp.Data, apiResp.Meta, nil
+}
+
+// GetFaction retrieves information about a specific faction
+func (e *EndpointManager) GetFaction(ctx context.Context, factionSymbol string) (*schema.Faction, error) {
+	req := &transport.Request{
+		Method: "GET",
+		Path:   "/factions/" + factionSymbol,
+	}
+
+	resp, err := e.httpClient.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp schema.APIResponse[schema.Faction]
+	if err := json.Unmarshal(resp.Body, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal faction response: %w", err)
+	}
+
+	return &apiResp.Data, nil
+}
+
+// Helper functions for building query parameters
+
+func buildPaginationParams(opts *schema.PaginationOptions) url.Values {
+	if opts == nil {
+		return nil
+	}
+
+	params := url.Values{}
+	if opts.Page != nil {
+		params.Set("page", strconv.Itoa(*opts.Page))
+	}
+	if opts.Limit != nil {
+		params.Set("limit", strconv.Itoa(*opts.Limit))
+	}
+
+	return params
+}
+
+func buildWaypointFilterParams(opts *schema.WaypointFilterOptions) url.Values {
+	if opts == nil {
+		return nil
+	}
+
+	params := buildPaginationParams(&opts.PaginationOptions)
+	if opts.Type != "" {
+		params.Set("type", opts.Type)
+	}
+	for _, trait := range opts.Traits {
+		params.Add("traits", trait)
+	}
+
+	return params
 }
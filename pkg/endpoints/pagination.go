@@ -0,0 +1,149 @@
+package endpoints
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+)
+
+// MaxPageLimit is the largest page size accepted by the API
+const MaxPageLimit = 20
+
+// PageFetcher retrieves a single page of a paginated list endpoint
+type PageFetcher[T any] func(ctx context.Context, opts *schema.PaginationOptions) ([]T, *schema.Meta, error)
+
+// Iterator walks every item of a paginated list endpoint, fetching pages
+// lazily as it goes. Each page is a regular request, so iteration is paced
+// by the transport's rate limiter. Callers may stop at any point; no further
+// pages are requested.
+//
+//	it := client.Ships(ctx, nil)
+//	for it.Next() {
+//		ship := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFetcher[T]
+	page  int
+	limit int
+
+	items []T
+	index int
+	meta  *schema.Meta
+	done  bool
+	err   error
+}
+
+// NewIterator creates an iterator over the pages returned by fetch. opts may
+// be nil; Page selects the first page to fetch and Limit the page size,
+// defaulting to page 1 and MaxPageLimit.
+func NewIterator[T any](ctx context.Context, opts *schema.PaginationOptions, fetch PageFetcher[T]) *Iterator[T] {
+	it := &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		page:  1,
+		limit: MaxPageLimit,
+	}
+
+	if opts != nil {
+		if opts.Page != nil {
+			it.page = *opts.Page
+		}
+		if opts.Limit != nil {
+			it.limit = *opts.Limit
+		}
+	}
+
+	return it
+}
+
+// Next advances to the next item, fetching the next page when the current
+// one is exhausted. It returns false when iteration is complete or an error
+// occurred; check Err to tell them apart.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.index < len(it.items) {
+		it.index++
+	}
+
+	for it.index >= len(it.items) {
+		if it.done {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	return true
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.items[it.index]
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Meta returns the pagination metadata of the most recently fetched page,
+// or nil before the first page has been fetched
+func (it *Iterator[T]) Meta() *schema.Meta {
+	return it.meta
+}
+
+// All drains the iterator, returning every remaining item
+func (it *Iterator[T]) All() ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// fetchPage requests the next page and decides whether more remain based on meta.total
+func (it *Iterator[T]) fetchPage() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+
+	page, limit := it.page, it.limit
+	items, meta, err := it.fetch(it.ctx, &schema.PaginationOptions{Page: &page, Limit: &limit})
+	if err != nil {
+		return err
+	}
+
+	it.items = items
+	it.index = 0
+	it.meta = meta
+	it.page++
+
+	// Without metadata there is no way to know whether more pages exist
+	if len(items) == 0 || meta == nil {
+		it.done = true
+		return nil
+	}
+
+	pageLimit := meta.Limit
+	if pageLimit <= 0 {
+		pageLimit = limit
+	}
+	if page*pageLimit >= meta.Total {
+		it.done = true
+	}
+
+	return nil
+}
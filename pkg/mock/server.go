@@ -4,20 +4,44 @@ import (
 	"encoding/json"
 	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Simulated game rules
+const (
+	siphonYieldUnits = 7
+	siphonCooldown   = 70 * time.Second
+
+	errorCodeCooldownConflict = 4000
+
+	defaultPageLimit = 10
+	maxPageLimit     = 20
+)
+
+// factionSymbols lists the factions served by the mock, in API order
+var factionSymbols = []string{
+	"COSMIC", "VOID", "GALACTIC", "QUANTUM", "DOMINION",
+	"ASTRO", "CORSAIRS", "OBSIDIAN", "AEGIS", "UNITED",
+	"SOLITARY", "COBALT", "OMEGA", "ECHO", "LORDS",
+	"CULT", "ANCIENTS", "SHADOW", "ETHERIC",
+}
+
 // MockServer simulates the SpaceTraders API with business logic
 type MockServer struct {
 	server      *httptest.Server
 	rateLimiter *ratelimit.TokenBucket
 	gameState   *GameState
 	mutex       sync.RWMutex
+
+	requestCount uint64 // Used to assign request IDs
 }
 
 // GameState represents the simulated game state
@@ -26,6 +50,8 @@ type GameState struct {
 	Ships     map[string]*schema.Ship     `json:"ships"`
 	Contracts map[string]*schema.Contract `json:"contracts"`
 	Markets   map[string]*schema.Market   `json:"markets"`
+	Shipyards map[string]*schema.Shipyard `json:"shipyards"`
+	Cooldowns map[string]schema.Cooldown  `json:"cooldowns"` // ship symbol -> reactor cooldown
 	Systems   map[string]*schema.System   `json:"systems"`
 	Waypoints map[string]*schema.Waypoint `json:"waypoints"`
 	Tokens    map[string]string           `json:"tokens"` // token -> agent symbol
@@ -44,6 +70,8 @@ func NewMockServer() *MockServer {
 		Ships:        make(map[string]*schema.Ship),
 		Contracts:    make(map[string]*schema.Contract),
 		Markets:      make(map[string]*schema.Market),
+		Shipyards:    make(map[string]*schema.Shipyard),
+		Cooldowns:    make(map[string]schema.Cooldown),
 		Systems:      make(map[string]*schema.System),
 		Waypoints:    make(map[string]*schema.Waypoint),
 		Tokens:       make(map[string]string),
@@ -93,14 +121,22 @@ func (m *MockServer) setupRoutes(mux *http.ServeMux) {
 	// Agent registration (no auth middleware)
 	mux.HandleFunc("/register", m.withRateLimit(m.handleRegister))
 
+	// Server status and public agents (no auth middleware)
+	mux.HandleFunc("/", m.withRateLimit(m.handleServerStatus))
+	mux.HandleFunc("/agents", m.withRateLimit(m.handleListAgents))
+	mux.HandleFunc("/agents/", m.withRateLimit(m.handleGetPublicAgent))
+	mux.HandleFunc("/factions", m.withRateLimit(m.handleListFactions))
+	mux.HandleFunc("/factions/", m.withRateLimit(m.handleGetFaction))
+
 	// Agent operations (with auth middleware)
 	mux.HandleFunc("/my/agent", m.withMiddleware(m.handleGetAgent))
 
 	// Ship operations (with auth middleware)
-	mux.HandleFunc("/my/ships", m.withMiddleware(m.handleGetFleet))
+	mux.HandleFunc("/my/ships", m.withMiddleware(m.handleShips))
 	mux.HandleFunc("/my/ships/", m.withMiddleware(m.handleShipOperations))
 
-	// Market operations (with auth middleware)
+	// System, waypoint and market operations (with auth middleware)
+	mux.HandleFunc("/systems", m.withMiddleware(m.handleGetSystems))
 	mux.HandleFunc("/systems/", m.withMiddleware(m.handleSystemOperations))
 
 	// Contract operations (with auth middleware)
@@ -118,6 +154,7 @@ func (m *MockServer) withMiddleware(handler http.HandlerFunc) http.HandlerFunc {
 				return
 			}
 		}
+		m.setResponseHeaders(w)
 
 		// Authentication (except for registration)
 		if r.URL.Path != "/register" {
@@ -142,6 +179,7 @@ func (m *MockServer) withRateLimit(handler http.HandlerFunc) http.HandlerFunc {
 				return
 			}
 		}
+		m.setResponseHeaders(w)
 
 		// Call the actual handler
 		handler(w, r)
@@ -201,6 +239,147 @@ func (m *MockServer) handleRegister(w http.ResponseWriter, r *http.Request) {
 	m.writeJSONResponse(w, http.StatusCreated, response)
 }
 
+// Server status handler
+func (m *MockServer) handleServerStatus(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m.mutex.RLock()
+	agents := m.publicAgents()
+	status := schema.ServerStatus{
+		Status:      "SpaceTraders mock server is online",
+		Version:     "v2.0.0-mock",
+		ResetDate:   m.gameState.LastUpdate.Format("2006-01-02"),
+		Description: "Mock SpaceTraders API for testing",
+		Stats: schema.ServerStats{
+			Agents:    len(m.gameState.Agents),
+			Ships:     len(m.gameState.Ships),
+			Systems:   len(m.gameState.Systems),
+			Waypoints: len(m.gameState.Waypoints),
+		},
+		ServerResets: schema.ServerResets{
+			Next:      m.gameState.LastUpdate.Add(7 * 24 * time.Hour).UTC().Truncate(time.Second),
+			Frequency: "weekly",
+		},
+		Announcements: []schema.Announcement{},
+		Links:         []schema.ServerLink{},
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].Credits > agents[j].Credits
+	})
+	status.Leaderboards.MostCredits = []schema.CreditsLeader{}
+	status.Leaderboards.MostSubmittedCharts = []schema.ChartsLeader{}
+	for _, agent := range agents {
+		status.Leaderboards.MostCredits = append(status.Leaderboards.MostCredits, schema.CreditsLeader{
+			AgentSymbol: agent.Symbol,
+			Credits:     agent.Credits,
+		})
+	}
+
+	// The status endpoint is not wrapped in a data envelope
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
+
+// List public agents handler
+func (m *MockServer) handleListAgents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m.mutex.RLock()
+	agents := m.publicAgents()
+	m.mutex.RUnlock()
+
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].Symbol < agents[j].Symbol
+	})
+
+	writePage(m, w, r, agents)
+}
+
+// Get public agent handler
+func (m *MockServer) handleGetPublicAgent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	agentSymbol := strings.TrimPrefix(r.URL.Path, "/agents/")
+
+	m.mutex.RLock()
+	agent, exists := m.gameState.Agents[agentSymbol]
+	var result schema.Agent
+	if exists {
+		result = *agent
+		result.AccountID = ""
+	}
+	m.mutex.RUnlock()
+
+	if !exists {
+		m.writeError(w, http.StatusNotFound, "Agent not found")
+		return
+	}
+
+	m.writeJSONResponse(w, http.StatusOK, result)
+}
+
+// publicAgents returns copies of all agents without private account details (must be called with mutex held)
+func (m *MockServer) publicAgents() []schema.Agent {
+	agents := make([]schema.Agent, 0, len(m.gameState.Agents))
+	for _, agent := range m.gameState.Agents {
+		public := *agent
+		public.AccountID = ""
+		agents = append(agents, public)
+	}
+
+	return agents
+}
+
+// List factions handler
+func (m *MockServer) handleListFactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	factions := make([]schema.Faction, 0, len(factionSymbols))
+	for _, symbol := range factionSymbols {
+		factions = append(factions, *m.getFaction(symbol))
+	}
+
+	writePage(m, w, r, factions)
+}
+
+// Get faction handler
+func (m *MockServer) handleGetFaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	factionSymbol := strings.TrimPrefix(r.URL.Path, "/factions/")
+	for _, symbol := range factionSymbols {
+		if symbol == factionSymbol {
+			m.writeJSONResponse(w, http.StatusOK, *m.getFaction(symbol))
+			return
+		}
+	}
+
+	m.writeError(w, http.StatusNotFound, "Faction not found")
+}
+
 // Get agent handler
 func (m *MockServer) handleGetAgent(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -226,6 +405,16 @@ func (m *MockServer) handleGetAgent(w http.ResponseWriter, r *http.Request) {
 	m.writeJSONResponse(w, http.StatusOK, *agent)
 }
 
+// Fleet handler: lists ships on GET and purchases a ship on POST
+func (m *MockServer) handleShips(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		m.handlePurchaseShip(w, r)
+		return
+	}
+
+	m.handleGetFleet(w, r)
+}
+
 // Get fleet handler
 func (m *MockServer) handleGetFleet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -248,7 +437,11 @@ func (m *MockServer) handleGetFleet(w http.ResponseWriter, r *http.Request) {
 	}
 	m.mutex.RUnlock()
 
-	m.writeJSONResponse(w, http.StatusOK, ships)
+	sort.Slice(ships, func(i, j int) bool {
+		return ships[i].Symbol < ships[j].Symbol
+	})
+
+	writePage(m, w, r, ships)
 }
 
 // Ship operations handler
@@ -284,10 +477,95 @@ func (m *MockServer) handleShipOperations(w http.ResponseWriter, r *http.Request
 			m.handlePurchaseCargo(w, r, shipSymbol)
 		case "sell":
 			m.handleSellCargo(w, r, shipSymbol)
+		case "cooldown":
+			m.handleGetShipCooldown(w, r, shipSymbol)
+		case "siphon":
+			m.handleSiphonResources(w, r, shipSymbol)
 		default:
 			http.Error(w, "Unknown operation", http.StatusNotFound)
 		}
 	}
+
+	if len(pathParts) == 5 {
+		// POST /my/ships/{shipSymbol}/negotiate/contract
+		if pathParts[3] == "negotiate" && pathParts[4] == "contract" {
+			m.handleNegotiateContract(w, r, shipSymbol)
+			return
+		}
+		http.Error(w, "Unknown operation", http.StatusNotFound)
+	}
+}
+
+// Get ship cooldown handler; responds 204 when the ship has no active cooldown
+func (m *MockServer) handleGetShipCooldown(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+
+	m.mutex.RLock()
+	ship, exists := m.gameState.Ships[shipSymbol]
+	cooldown, onCooldown := m.gameState.Cooldowns[shipSymbol]
+	m.mutex.RUnlock()
+
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
+
+	if !onCooldown || cooldown.Expiration == nil || !time.Now().Before(*cooldown.Expiration) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	cooldown.RemainingSeconds = int(time.Until(*cooldown.Expiration).Seconds())
+	m.writeJSONResponse(w, http.StatusOK, cooldown)
+}
+
+// Negotiate contract handler
+func (m *MockServer) handleNegotiateContract(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ship, exists := m.gameState.Ships[shipSymbol]
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
+
+	if ship.Nav.Status != "DOCKED" {
+		m.writeError(w, http.StatusBadRequest, "Ship must be docked to negotiate a contract")
+		return
+	}
+
+	count := 0
+	for _, contract := range m.gameState.Contracts {
+		if !isAgentContract(contract, agentSymbol) {
+			continue
+		}
+		if !contract.Fulfilled {
+			m.writeError(w, http.StatusBadRequest, "Agent already has an active contract")
+			return
+		}
+		count++
+	}
+
+	agent := m.gameState.Agents[agentSymbol]
+	contract := m.createContract(agent, count+1)
+	m.gameState.Contracts[contract.ID] = contract
+
+	m.writeJSONResponse(w, http.StatusCreated, schema.NegotiateContractResponse{
+		Contract: *contract,
+	})
 }
 
 // Business logic methods
@@ -329,9 +607,54 @@ func (m *MockServer) createStartingShip(agent *schema.Agent) *schema.Ship {
 	}
 }
 
+// createShip builds a newly purchased ship; agent.ShipCount must already include it
+func (m *MockServer) createShip(agent *schema.Agent, listing *schema.ShipyardShip, waypointSymbol string) *schema.Ship {
+	waypoint := m.gameState.Waypoints[waypointSymbol]
+
+	return &schema.Ship{
+		Symbol: agent.Symbol + "-" + strings.ToUpper(strconv.FormatInt(int64(agent.ShipCount), 16)),
+		Registration: schema.Registration{
+			Name:          listing.Name,
+			FactionSymbol: agent.StartingFaction,
+			Role:          shipRoles[listing.Type],
+		},
+		Nav: schema.Navigation{
+			SystemSymbol:   waypoint.SystemSymbol,
+			WaypointSymbol: waypointSymbol,
+			Status:         "DOCKED",
+			FlightMode:     "CRUISE",
+		},
+		Frame:   listing.Frame,
+		Reactor: listing.Reactor,
+		Engine:  listing.Engine,
+		Modules: listing.Modules,
+		Mounts:  listing.Mounts,
+		Cargo: schema.Cargo{
+			Capacity:  15,
+			Units:     0,
+			Inventory: []schema.CargoItem{},
+		},
+		Fuel: schema.Fuel{
+			Current:  listing.Frame.FuelCapacity,
+			Capacity: listing.Frame.FuelCapacity,
+		},
+	}
+}
+
+// shipRoles maps purchasable ship types to their registration role
+var shipRoles = map[string]string{
+	"SHIP_MINING_DRONE": "EXCAVATOR",
+	"SHIP_PROBE":        "SATELLITE",
+}
+
 func (m *MockServer) createStartingContract(agent *schema.Agent) *schema.Contract {
+	return m.createContract(agent, 1)
+}
+
+// createContract builds the agent's nth procurement contract
+func (m *MockServer) createContract(agent *schema.Agent, n int) *schema.Contract {
 	return &schema.Contract{
-		ID:            "contract-" + agent.Symbol + "-1",
+		ID:            "contract-" + agent.Symbol + "-" + strconv.Itoa(n),
 		FactionSymbol: agent.StartingFaction,
 		Type:          "PROCUREMENT",
 		Terms: schema.ContractTerms{
@@ -415,13 +738,60 @@ func (m *MockServer) writeJSONResponse(w http.ResponseWriter, statusCode int, da
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
-	response := schema.APIResponse{
+	response := schema.APIResponse[interface{}]{
 		Data: data,
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
+// writePage writes the page of items selected by the page and limit query
+// parameters, along with pagination metadata
+func writePage[T any](m *MockServer, w http.ResponseWriter, r *http.Request, items []T) {
+	page, limit := 1, defaultPageLimit
+	query := r.URL.Query()
+
+	if value := query.Get("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			m.writeError(w, http.StatusBadRequest, "Invalid page")
+			return
+		}
+		page = n
+	}
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxPageLimit {
+			m.writeError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = n
+	}
+
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := schema.APIResponse[[]T]{
+		Data: items[start:end],
+		Meta: &schema.Meta{
+			Total: len(items),
+			Page:  page,
+			Limit: limit,
+		},
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 func (m *MockServer) writeError(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -434,6 +804,40 @@ func (m *MockServer) writeError(w http.ResponseWriter, statusCode int, message s
 	json.NewEncoder(w).Encode(errorResp)
 }
 
+// writeCooldownError reports a 409 cooldown conflict carrying the remaining cooldown
+func (m *MockServer) writeCooldownError(w http.ResponseWriter, cooldown schema.Cooldown) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+
+	errorResp := schema.APIError{
+		Message: "Ship action is still on cooldown",
+		Code:    errorCodeCooldownConflict,
+		Data: map[string]interface{}{
+			"cooldown": cooldown,
+		},
+	}
+
+	json.NewEncoder(w).Encode(errorResp)
+}
+
+// setResponseHeaders sets the request ID and rate limit headers sent with successful responses
+func (m *MockServer) setResponseHeaders(w http.ResponseWriter) {
+	w.Header().Set("x-request-id", "mock-"+strconv.FormatUint(atomic.AddUint64(&m.requestCount, 1), 10))
+
+	rateLimiter := m.rateLimiter
+	if rateLimiter == nil {
+		return
+	}
+
+	state := rateLimiter.GetState()
+	reset := time.Now().Add(time.Duration(state.Capacity-state.Tokens) * state.RefillRate)
+	w.Header().Set("x-ratelimit-type", "IP-based")
+	w.Header().Set("x-ratelimit-limit-burst", strconv.Itoa(state.Capacity))
+	w.Header().Set("x-ratelimit-limit-per-second", strconv.Itoa(int(time.Second/state.RefillRate)))
+	w.Header().Set("x-ratelimit-remaining", strconv.Itoa(state.Tokens))
+	w.Header().Set("x-ratelimit-reset", reset.UTC().Format(time.RFC3339Nano))
+}
+
 func (m *MockServer) writeRateLimitError(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("x-ratelimit-type", "requests")
@@ -480,10 +884,43 @@ func (gs *GameState) initializeGameData() {
 				Name:        "Marketplace",
 				Description: "A bustling marketplace",
 			},
+			{
+				Symbol:      "SHIPYARD",
+				Name:        "Shipyard",
+				Description: "A facility for building and selling ships",
+			},
 		},
 	}
 	gs.Waypoints[waypoint.Symbol] = waypoint
 
+	asteroid := &schema.Waypoint{
+		Symbol:       "X1-TEST-B2",
+		Type:         "ASTEROID",
+		SystemSymbol: "X1-TEST",
+		X:            10,
+		Y:            -5,
+		Traits: []schema.Trait{
+			{
+				Symbol:      "COMMON_METAL_DEPOSITS",
+				Name:        "Common Metal Deposits",
+				Description: "Deposits of common metals",
+			},
+		},
+	}
+	gs.Waypoints[asteroid.Symbol] = asteroid
+
+	gasGiant := &schema.Waypoint{
+		Symbol:       "X1-TEST-C3",
+		Type:         "GAS_GIANT",
+		SystemSymbol: "X1-TEST",
+		X:            -20,
+		Y:            15,
+		Traits:       []schema.Trait{},
+	}
+	gs.Waypoints[gasGiant.Symbol] = gasGiant
+
+	system.Waypoints = []schema.Waypoint{*waypoint, *asteroid, *gasGiant}
+
 	// Add sample market
 	market := &schema.Market{
 		Symbol: "X1-TEST-A1",
@@ -504,6 +941,48 @@ func (gs *GameState) initializeGameData() {
 	}
 	gs.Markets[market.Symbol] = market
 
+	// Add sample shipyard
+	shipyard := &schema.Shipyard{
+		Symbol: "X1-TEST-A1",
+		ShipTypes: []schema.ShipyardShipType{
+			{Type: "SHIP_MINING_DRONE"},
+			{Type: "SHIP_PROBE"},
+		},
+		Ships: []schema.ShipyardShip{
+			{
+				Type:          "SHIP_MINING_DRONE",
+				Name:          "Mining Drone",
+				Description:   "A small, inexpensive mining ship",
+				Supply:        "ABUNDANT",
+				PurchasePrice: 25000,
+				Frame: schema.Frame{
+					Symbol:       "FRAME_DRONE",
+					Name:         "Drone",
+					Condition:    1,
+					Integrity:    1,
+					FuelCapacity: 100,
+				},
+				Crew: schema.ShipyardShipCrew{Required: 0, Capacity: 0},
+			},
+			{
+				Type:          "SHIP_PROBE",
+				Name:          "Probe",
+				Description:   "A small, unmanned satellite",
+				Supply:        "ABUNDANT",
+				PurchasePrice: 20000,
+				Frame: schema.Frame{
+					Symbol:    "FRAME_PROBE",
+					Name:      "Probe",
+					Condition: 1,
+					Integrity: 1,
+				},
+				Crew: schema.ShipyardShipCrew{Required: 0, Capacity: 0},
+			},
+		},
+		ModificationsFee: 1000,
+	}
+	gs.Shipyards[shipyard.Symbol] = shipyard
+
 	// Initialize fuel prices
 	gs.FuelPrices["X1-TEST-A1"] = 100
 
@@ -520,47 +999,716 @@ func (m *MockServer) handleGetShip(w http.ResponseWriter, r *http.Request, shipS
 	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
 }
 
+// Orbit handler; moves a docked ship into orbit
 func (m *MockServer) handleShipOrbit(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would change ship status to orbiting
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+	m.handleShipStatusChange(w, r, shipSymbol, "IN_ORBIT")
 }
 
+// Dock handler; docks an orbiting ship at its current waypoint
 func (m *MockServer) handleShipDock(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would change ship status to docked
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+	m.handleShipStatusChange(w, r, shipSymbol, "DOCKED")
 }
 
-func (m *MockServer) handleShipNavigate(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would handle navigation with fuel consumption and travel time
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
-}
+func (m *MockServer) handleShipStatusChange(w http.ResponseWriter, r *http.Request, shipSymbol, status string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
 
-func (m *MockServer) handleShipRefuel(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would handle refueling with cost calculation
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
-}
+	agentSymbol := m.getAgentFromToken(r)
 
-func (m *MockServer) handlePurchaseCargo(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would handle cargo purchase with market price calculations
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
-}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
-func (m *MockServer) handleSellCargo(w http.ResponseWriter, r *http.Request, shipSymbol string) {
-	// Implementation would handle cargo sales with market price calculations
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
-}
+	ship, exists := m.gameState.Ships[shipSymbol]
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
 
-func (m *MockServer) handleSystemOperations(w http.ResponseWriter, r *http.Request) {
-	// Implementation would handle system and waypoint operations
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
-}
+	updateShipArrival(ship)
+	if ship.Nav.Status == "IN_TRANSIT" {
+		m.writeError(w, http.StatusBadRequest, "Ship is currently in transit")
+		return
+	}
 
-func (m *MockServer) handleGetContracts(w http.ResponseWriter, r *http.Request) {
-	// Implementation would return available contracts
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+	ship.Nav.Status = status
+	m.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+		"nav": ship.Nav,
+	})
 }
 
-func (m *MockServer) handleContractOperations(w http.ResponseWriter, r *http.Request) {
-	// Implementation would handle contract accept/fulfill operations
-	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+// Navigate handler; consumes fuel by distance and uses TravelTimes for the
+// flight duration, arriving immediately when no travel time is configured
+func (m *MockServer) handleShipNavigate(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req schema.NavigateShipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		m.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ship, exists := m.gameState.Ships[shipSymbol]
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
+
+	updateShipArrival(ship)
+	if ship.Nav.Status != "IN_ORBIT" {
+		m.writeError(w, http.StatusBadRequest, "Ship must be in orbit to navigate")
+		return
+	}
+
+	origin, exists := m.gameState.Waypoints[ship.Nav.WaypointSymbol]
+	if !exists {
+		m.writeError(w, http.StatusBadRequest, "Ship is at an unknown waypoint")
+		return
+	}
+	destination, exists := m.gameState.Waypoints[req.WaypointSymbol]
+	if !exists || destination.SystemSymbol != ship.Nav.SystemSymbol {
+		m.writeError(w, http.StatusBadRequest, "Destination is not in the ship's system")
+		return
+	}
+	if destination.Symbol == origin.Symbol {
+		m.writeError(w, http.StatusBadRequest, "Ship is already at the destination")
+		return
+	}
+
+	dx := float64(destination.X - origin.X)
+	dy := float64(destination.Y - origin.Y)
+	fuelCost := int(math.Max(1, math.Round(math.Sqrt(dx*dx+dy*dy))))
+	if ship.Fuel.Capacity == 0 {
+		fuelCost = 0
+	}
+	if fuelCost > ship.Fuel.Current {
+		m.writeError(w, http.StatusBadRequest, "Ship does not have enough fuel")
+		return
+	}
+
+	now := time.Now()
+	ship.Fuel.Current -= fuelCost
+	ship.Fuel.Consumed = &schema.FuelUsed{Amount: fuelCost, Timestamp: now}
+	ship.Nav.WaypointSymbol = destination.Symbol
+	ship.Nav.Route = schema.Route{
+		Origin:        routeWaypoint(origin),
+		Destination:   routeWaypoint(destination),
+		DepartureTime: now,
+		Arrival:       now.Add(m.gameState.TravelTimes[origin.Symbol][destination.Symbol]),
+	}
+	ship.Nav.Status = "IN_TRANSIT"
+	updateShipArrival(ship)
+
+	m.writeJSONResponse(w, http.StatusOK, schema.NavigateResult{
+		Nav:    ship.Nav,
+		Fuel:   ship.Fuel,
+		Events: []schema.ShipConditionEvent{},
+	})
+}
+
+// Siphon handler; yields hydrocarbon into cargo and puts the ship on cooldown
+func (m *MockServer) handleSiphonResources(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ship, exists := m.gameState.Ships[shipSymbol]
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
+
+	updateShipArrival(ship)
+	if ship.Nav.Status != "IN_ORBIT" {
+		m.writeError(w, http.StatusBadRequest, "Ship must be in orbit to siphon")
+		return
+	}
+
+	waypoint, exists := m.gameState.Waypoints[ship.Nav.WaypointSymbol]
+	if !exists || waypoint.Type != "GAS_GIANT" {
+		m.writeError(w, http.StatusBadRequest, "Ship must be at a gas giant to siphon")
+		return
+	}
+
+	if cooldown, ok := m.gameState.Cooldowns[shipSymbol]; ok && cooldown.Expiration != nil && time.Now().Before(*cooldown.Expiration) {
+		cooldown.RemainingSeconds = int(time.Until(*cooldown.Expiration).Seconds())
+		m.writeCooldownError(w, cooldown)
+		return
+	}
+
+	units := ship.Cargo.Capacity - ship.Cargo.Units
+	if units > siphonYieldUnits {
+		units = siphonYieldUnits
+	}
+	if units <= 0 {
+		m.writeError(w, http.StatusBadRequest, "Ship cargo is full")
+		return
+	}
+	addCargo(&ship.Cargo, schema.CargoItem{
+		Symbol:      "HYDROCARBON",
+		Name:        "Hydrocarbon",
+		Description: "Gaseous hydrocarbons siphoned from a gas giant",
+		Units:       units,
+	})
+
+	expiration := time.Now().Add(siphonCooldown)
+	cooldown := schema.Cooldown{
+		ShipSymbol:       shipSymbol,
+		TotalSeconds:     int(siphonCooldown.Seconds()),
+		RemainingSeconds: int(siphonCooldown.Seconds()),
+		Expiration:       &expiration,
+	}
+	m.gameState.Cooldowns[shipSymbol] = cooldown
+
+	m.writeJSONResponse(w, http.StatusCreated, schema.SiphonResourcesResponse{
+		Cooldown: cooldown,
+		Siphon: schema.Siphon{
+			ShipSymbol: shipSymbol,
+			Yield:      schema.SiphonYield{Symbol: "HYDROCARBON", Units: units},
+		},
+		Cargo:  ship.Cargo,
+		Events: []schema.ShipConditionEvent{},
+	})
+}
+
+func (m *MockServer) handleShipRefuel(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	// Implementation would handle refueling with cost calculation
+	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+}
+
+func (m *MockServer) handlePurchaseCargo(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	// Implementation would handle cargo purchase with market price calculations
+	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+}
+
+func (m *MockServer) handleSellCargo(w http.ResponseWriter, r *http.Request, shipSymbol string) {
+	// Implementation would handle cargo sales with market price calculations
+	m.writeError(w, http.StatusNotImplemented, "Not implemented in basic version")
+}
+
+// Get systems handler
+func (m *MockServer) handleGetSystems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	m.mutex.RLock()
+	systems := []schema.System{}
+	for _, system := range m.gameState.Systems {
+		systems = append(systems, *system)
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(systems, func(i, j int) bool {
+		return systems[i].Symbol < systems[j].Symbol
+	})
+
+	writePage(m, w, r, systems)
+}
+
+// System, waypoint and market operations handler
+func (m *MockServer) handleSystemOperations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 2 || (len(pathParts) > 2 && pathParts[2] != "waypoints") {
+		http.Error(w, "Invalid path", http.StatusNotFound)
+		return
+	}
+
+	systemSymbol := pathParts[1]
+
+	switch len(pathParts) {
+	case 2:
+		// GET /systems/{systemSymbol}
+		m.mutex.RLock()
+		system, exists := m.gameState.Systems[systemSymbol]
+		var result schema.System
+		if exists {
+			result = *system
+		}
+		m.mutex.RUnlock()
+
+		if !exists {
+			m.writeError(w, http.StatusNotFound, "System not found")
+			return
+		}
+		m.writeJSONResponse(w, http.StatusOK, result)
+	case 3:
+		// GET /systems/{systemSymbol}/waypoints
+		m.handleGetWaypoints(w, r, systemSymbol)
+	case 4:
+		// GET /systems/{systemSymbol}/waypoints/{waypointSymbol}
+		m.mutex.RLock()
+		waypoint, exists := m.gameState.Waypoints[pathParts[3]]
+		var result schema.Waypoint
+		if exists {
+			result = *waypoint
+		}
+		m.mutex.RUnlock()
+
+		if !exists || result.SystemSymbol != systemSymbol {
+			m.writeError(w, http.StatusNotFound, "Waypoint not found")
+			return
+		}
+		m.writeJSONResponse(w, http.StatusOK, result)
+	case 5:
+		switch pathParts[4] {
+		case "market":
+			m.handleGetMarket(w, r, pathParts[3])
+		case "shipyard":
+			m.handleGetShipyard(w, r, pathParts[3])
+		default:
+			http.Error(w, "Unknown operation", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "Invalid path", http.StatusNotFound)
+	}
+}
+
+func (m *MockServer) handleGetWaypoints(w http.ResponseWriter, r *http.Request, systemSymbol string) {
+	query := r.URL.Query()
+	waypointType := query.Get("type")
+	traits := query["traits"]
+
+	m.mutex.RLock()
+	if _, exists := m.gameState.Systems[systemSymbol]; !exists {
+		m.mutex.RUnlock()
+		m.writeError(w, http.StatusNotFound, "System not found")
+		return
+	}
+
+	waypoints := []schema.Waypoint{}
+	for _, waypoint := range m.gameState.Waypoints {
+		if waypoint.SystemSymbol != systemSymbol {
+			continue
+		}
+		if waypointType != "" && waypoint.Type != waypointType {
+			continue
+		}
+		if !hasAllTraits(waypoint, traits) {
+			continue
+		}
+		waypoints = append(waypoints, *waypoint)
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(waypoints, func(i, j int) bool {
+		return waypoints[i].Symbol < waypoints[j].Symbol
+	})
+
+	writePage(m, w, r, waypoints)
+}
+
+func (m *MockServer) handleGetMarket(w http.ResponseWriter, r *http.Request, waypointSymbol string) {
+	m.mutex.RLock()
+	market, exists := m.gameState.Markets[waypointSymbol]
+	var result schema.Market
+	if exists {
+		result = *market
+	}
+	m.mutex.RUnlock()
+
+	if !exists {
+		m.writeError(w, http.StatusNotFound, "Market not found")
+		return
+	}
+
+	m.writeJSONResponse(w, http.StatusOK, result)
+}
+
+func (m *MockServer) handleGetShipyard(w http.ResponseWriter, r *http.Request, waypointSymbol string) {
+	m.mutex.RLock()
+	shipyard, exists := m.gameState.Shipyards[waypointSymbol]
+	var result schema.Shipyard
+	if exists {
+		result = *shipyard
+	}
+	m.mutex.RUnlock()
+
+	if !exists {
+		m.writeError(w, http.StatusNotFound, "Shipyard not found")
+		return
+	}
+
+	m.writeJSONResponse(w, http.StatusOK, result)
+}
+
+// Purchase ship handler
+func (m *MockServer) handlePurchaseShip(w http.ResponseWriter, r *http.Request) {
+	agentSymbol := m.getAgentFromToken(r)
+	if agentSymbol == "" {
+		m.writeAuthError(w)
+		return
+	}
+
+	var req schema.PurchaseShipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		m.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	shipyard, exists := m.gameState.Shipyards[req.WaypointSymbol]
+	if !exists {
+		m.writeError(w, http.StatusNotFound, "Shipyard not found")
+		return
+	}
+
+	var listing *schema.ShipyardShip
+	for i := range shipyard.Ships {
+		if shipyard.Ships[i].Type == req.ShipType {
+			listing = &shipyard.Ships[i]
+			break
+		}
+	}
+	if listing == nil {
+		m.writeError(w, http.StatusBadRequest, "Ship type not sold at this shipyard")
+		return
+	}
+
+	// A ship must be present at the shipyard to make a purchase
+	present := false
+	for _, ship := range m.gameState.Ships {
+		if strings.HasPrefix(ship.Symbol, agentSymbol+"-") && ship.Nav.WaypointSymbol == req.WaypointSymbol {
+			present = true
+			break
+		}
+	}
+	if !present {
+		m.writeError(w, http.StatusBadRequest, "No ship present at the shipyard")
+		return
+	}
+
+	agent := m.gameState.Agents[agentSymbol]
+	if agent.Credits < int64(listing.PurchasePrice) {
+		m.writeError(w, http.StatusBadRequest, "Insufficient credits")
+		return
+	}
+
+	agent.Credits -= int64(listing.PurchasePrice)
+	agent.ShipCount++
+
+	ship := m.createShip(agent, listing, req.WaypointSymbol)
+	m.gameState.Ships[ship.Symbol] = ship
+
+	transaction := schema.ShipyardTransaction{
+		WaypointSymbol: req.WaypointSymbol,
+		ShipType:       listing.Type,
+		Price:          listing.PurchasePrice,
+		AgentSymbol:    agentSymbol,
+		Timestamp:      time.Now(),
+	}
+	shipyard.Transactions = append(shipyard.Transactions, transaction)
+
+	m.writeJSONResponse(w, http.StatusCreated, schema.PurchaseShipResponse{
+		Agent:       *agent,
+		Ship:        *ship,
+		Transaction: transaction,
+	})
+}
+
+// hasAllTraits reports whether a waypoint has every one of the given traits
+func hasAllTraits(waypoint *schema.Waypoint, traits []string) bool {
+	for _, trait := range traits {
+		found := false
+		for _, t := range waypoint.Traits {
+			if t.Symbol == trait {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *MockServer) handleGetContracts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+	if agentSymbol == "" {
+		m.writeAuthError(w)
+		return
+	}
+
+	m.mutex.RLock()
+	contracts := []schema.Contract{}
+	for _, contract := range m.gameState.Contracts {
+		if isAgentContract(contract, agentSymbol) {
+			contracts = append(contracts, *contract)
+		}
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].ID < contracts[j].ID
+	})
+
+	writePage(m, w, r, contracts)
+}
+
+// Contract operations handler
+func (m *MockServer) handleContractOperations(w http.ResponseWriter, r *http.Request) {
+	pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(pathParts) < 3 {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+
+	agentSymbol := m.getAgentFromToken(r)
+	if agentSymbol == "" {
+		m.writeAuthError(w)
+		return
+	}
+
+	contractID := pathParts[2]
+
+	if len(pathParts) == 3 {
+		// GET /my/contracts/{contractId}
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		m.mutex.RLock()
+		contract, exists := m.gameState.Contracts[contractID]
+		if !exists || !isAgentContract(contract, agentSymbol) {
+			m.mutex.RUnlock()
+			m.writeError(w, http.StatusNotFound, "Contract not found")
+			return
+		}
+		result := *contract
+		m.mutex.RUnlock()
+
+		m.writeJSONResponse(w, http.StatusOK, result)
+		return
+	}
+
+	if len(pathParts) != 4 {
+		http.Error(w, "Invalid path", http.StatusNotFound)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch pathParts[3] {
+	case "accept":
+		m.handleAcceptContract(w, r, agentSymbol, contractID)
+	case "deliver":
+		m.handleDeliverContract(w, r, agentSymbol, contractID)
+	case "fulfill":
+		m.handleFulfillContract(w, r, agentSymbol, contractID)
+	default:
+		http.Error(w, "Unknown operation", http.StatusNotFound)
+	}
+}
+
+func (m *MockServer) handleAcceptContract(w http.ResponseWriter, r *http.Request, agentSymbol, contractID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	contract, exists := m.gameState.Contracts[contractID]
+	if !exists || !isAgentContract(contract, agentSymbol) {
+		m.writeError(w, http.StatusNotFound, "Contract not found")
+		return
+	}
+
+	if contract.Accepted {
+		m.writeError(w, http.StatusBadRequest, "Contract has already been accepted")
+		return
+	}
+
+	agent := m.gameState.Agents[agentSymbol]
+	contract.Accepted = true
+	agent.Credits += int64(contract.Terms.Payment.OnAccepted)
+
+	m.writeJSONResponse(w, http.StatusOK, schema.AcceptContractResponse{
+		Agent:    *agent,
+		Contract: *contract,
+	})
+}
+
+func (m *MockServer) handleDeliverContract(w http.ResponseWriter, r *http.Request, agentSymbol, contractID string) {
+	var req schema.DeliverContractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		m.writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Units <= 0 {
+		m.writeError(w, http.StatusBadRequest, "Units must be positive")
+		return
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	contract, exists := m.gameState.Contracts[contractID]
+	if !exists || !isAgentContract(contract, agentSymbol) {
+		m.writeError(w, http.StatusNotFound, "Contract not found")
+		return
+	}
+
+	if !contract.Accepted || contract.Fulfilled {
+		m.writeError(w, http.StatusBadRequest, "Contract is not accepting deliveries")
+		return
+	}
+
+	ship, exists := m.gameState.Ships[req.ShipSymbol]
+	if !exists || !strings.HasPrefix(ship.Symbol, agentSymbol+"-") {
+		m.writeError(w, http.StatusNotFound, "Ship not found")
+		return
+	}
+
+	var deliverGood *schema.ContractDeliverGood
+	for i := range contract.Terms.Deliver {
+		if contract.Terms.Deliver[i].TradeSymbol == req.TradeSymbol {
+			deliverGood = &contract.Terms.Deliver[i]
+			break
+		}
+	}
+	if deliverGood == nil {
+		m.writeError(w, http.StatusBadRequest, "Trade good is not part of this contract")
+		return
+	}
+
+	if ship.Nav.WaypointSymbol != deliverGood.DestinationSymbol {
+		m.writeError(w, http.StatusBadRequest, "Ship is not at the delivery destination")
+		return
+	}
+
+	if deliverGood.UnitsFulfilled+req.Units > deliverGood.UnitsRequired {
+		m.writeError(w, http.StatusBadRequest, "Delivery exceeds units required")
+		return
+	}
+
+	if !removeCargo(&ship.Cargo, req.TradeSymbol, req.Units) {
+		m.writeError(w, http.StatusBadRequest, "Ship does not have enough cargo")
+		return
+	}
+
+	deliverGood.UnitsFulfilled += req.Units
+
+	m.writeJSONResponse(w, http.StatusOK, schema.DeliverContractResponse{
+		Contract: *contract,
+		Cargo:    ship.Cargo,
+	})
+}
+
+func (m *MockServer) handleFulfillContract(w http.ResponseWriter, r *http.Request, agentSymbol, contractID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	contract, exists := m.gameState.Contracts[contractID]
+	if !exists || !isAgentContract(contract, agentSymbol) {
+		m.writeError(w, http.StatusNotFound, "Contract not found")
+		return
+	}
+
+	if !contract.Accepted || contract.Fulfilled {
+		m.writeError(w, http.StatusBadRequest, "Contract cannot be fulfilled")
+		return
+	}
+
+	for _, good := range contract.Terms.Deliver {
+		if good.UnitsFulfilled < good.UnitsRequired {
+			m.writeError(w, http.StatusBadRequest, "Contract delivery terms have not been met")
+			return
+		}
+	}
+
+	agent := m.gameState.Agents[agentSymbol]
+	contract.Fulfilled = true
+	agent.Credits += int64(contract.Terms.Payment.OnFulfilled)
+
+	m.writeJSONResponse(w, http.StatusOK, schema.FulfillContractResponse{
+		Agent:    *agent,
+		Contract: *contract,
+	})
+}
+
+// isAgentContract reports whether a contract belongs to the given agent
+func isAgentContract(contract *schema.Contract, agentSymbol string) bool {
+	return strings.HasPrefix(contract.ID, "contract-"+agentSymbol+"-")
+}
+
+// removeCargo removes units of a good from cargo, returning false if there are not enough
+func removeCargo(cargo *schema.Cargo, symbol string, units int) bool {
+	for i, item := range cargo.Inventory {
+		if item.Symbol != symbol {
+			continue
+		}
+		if item.Units < units {
+			return false
+		}
+
+		cargo.Inventory[i].Units -= units
+		cargo.Units -= units
+		if cargo.Inventory[i].Units == 0 {
+			cargo.Inventory = append(cargo.Inventory[:i], cargo.Inventory[i+1:]...)
+		}
+		return true
+	}
+
+	return false
+}
+
+// addCargo adds units of a good to cargo, merging with an existing stack
+func addCargo(cargo *schema.Cargo, item schema.CargoItem) {
+	cargo.Units += item.Units
+	for i := range cargo.Inventory {
+		if cargo.Inventory[i].Symbol == item.Symbol {
+			cargo.Inventory[i].Units += item.Units
+			return
+		}
+	}
+	cargo.Inventory = append(cargo.Inventory, item)
+}
+
+// updateShipArrival completes the ship's flight once its arrival time has passed
+func updateShipArrival(ship *schema.Ship) {
+	if ship.Nav.Status == "IN_TRANSIT" && !time.Now().Before(ship.Nav.Route.Arrival) {
+		ship.Nav.Status = "IN_ORBIT"
+	}
+}
+
+func routeWaypoint(waypoint *schema.Waypoint) schema.RouteWaypoint {
+	return schema.RouteWaypoint{
+		Symbol:       waypoint.Symbol,
+		Type:         waypoint.Type,
+		SystemSymbol: waypoint.SystemSymbol,
+		X:            waypoint.X,
+		Y:            waypoint.Y,
+	}
 }
@@ -46,6 +46,14 @@ type Navigation struct {
 	FlightMode     string `json:"flightMode"`
 }
 
+// Ship flight modes
+const (
+	FlightModeDrift   = "DRIFT"
+	FlightModeStealth = "STEALTH"
+	FlightModeCruise  = "CRUISE"
+	FlightModeBurn    = "BURN"
+)
+
 // Route represents a navigation route
 type Route struct {
 	Destination   RouteWaypoint `json:"destination"`
@@ -78,8 +86,8 @@ type Frame struct {
 	Symbol         string           `json:"symbol"`
 	Name           string           `json:"name"`
 	Description    string           `json:"description"`
-	Condition      int              `json:"condition"`
-	Integrity      int              `json:"integrity"`
+	Condition      float64          `json:"condition"` // 0.0 (broken) to 1.0 (pristine)
+	Integrity      float64          `json:"integrity"` // 0.0 (broken) to 1.0 (pristine)
 	ModuleSlots    int              `json:"moduleSlots"`
 	MountingPoints int              `json:"mountingPoints"`
 	FuelCapacity   int              `json:"fuelCapacity"`
@@ -91,8 +99,8 @@ type Reactor struct {
 	Symbol       string           `json:"symbol"`
 	Name         string           `json:"name"`
 	Description  string           `json:"description"`
-	Condition    int              `json:"condition"`
-	Integrity    int              `json:"integrity"`
+	Condition    float64          `json:"condition"`
+	Integrity    float64          `json:"integrity"`
 	PowerOutput  int              `json:"powerOutput"`
 	Requirements ShipRequirements `json:"requirements"`
 }
@@ -102,8 +110,8 @@ type Engine struct {
 	Symbol       string           `json:"symbol"`
 	Name         string           `json:"name"`
 	Description  string           `json:"description"`
-	Condition    int              `json:"condition"`
-	Integrity    int              `json:"integrity"`
+	Condition    float64          `json:"condition"`
+	Integrity    float64          `json:"integrity"`
 	Speed        int              `json:"speed"`
 	Requirements ShipRequirements `json:"requirements"`
 }
@@ -162,6 +170,14 @@ type FuelUsed struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ShipConditionEvent represents damage or wear to a ship component during an action
+type ShipConditionEvent struct {
+	Symbol      string `json:"symbol"`
+	Component   string `json:"component"` // FRAME, REACTOR or ENGINE
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 // Contract represents a SpaceTraders contract
 type Contract struct {
 	ID               string        `json:"id"`
@@ -252,6 +268,8 @@ type Waypoint struct {
 	Modifiers    []Modifier `json:"modifiers,omitempty"`
 	Chart        *Chart     `json:"chart,omitempty"`
 	Faction      *Faction   `json:"faction,omitempty"`
+
+	IsUnderConstruction bool `json:"isUnderConstruction"`
 }
 
 // Orbital represents an orbital body
@@ -280,6 +298,65 @@ type Chart struct {
 	SubmittedOn    *time.Time `json:"submittedOn,omitempty"`
 }
 
+// JumpGate represents a jump gate and the waypoints it connects to
+type JumpGate struct {
+	Symbol      string   `json:"symbol"`
+	Connections []string `json:"connections"` // Symbols of connected jump gate waypoints
+}
+
+// ScannedSystem represents a system detected by a ship's sensors
+type ScannedSystem struct {
+	Symbol       string `json:"symbol"`
+	SectorSymbol string `json:"sectorSymbol"`
+	Type         string `json:"type"`
+	X            int    `json:"x"`
+	Y            int    `json:"y"`
+	Distance     int    `json:"distance"`
+}
+
+// ScannedWaypoint represents a waypoint detected by a ship's sensors
+type ScannedWaypoint struct {
+	Symbol       string    `json:"symbol"`
+	Type         string    `json:"type"`
+	SystemSymbol string    `json:"systemSymbol"`
+	X            int       `json:"x"`
+	Y            int       `json:"y"`
+	Orbitals     []Orbital `json:"orbitals"`
+	Faction      *Faction  `json:"faction,omitempty"`
+	Traits       []Trait   `json:"traits"`
+	Chart        *Chart    `json:"chart,omitempty"`
+}
+
+// ScannedShip represents another ship detected by a ship's sensors
+type ScannedShip struct {
+	Symbol       string                 `json:"symbol"`
+	Registration Registration           `json:"registration"`
+	Nav          Navigation             `json:"nav"`
+	Frame        *ScannedShipComponent  `json:"frame,omitempty"`
+	Reactor      *ScannedShipComponent  `json:"reactor,omitempty"`
+	Engine       ScannedShipComponent   `json:"engine"`
+	Mounts       []ScannedShipComponent `json:"mounts,omitempty"`
+}
+
+// ScannedShipComponent identifies a component of a scanned ship
+type ScannedShipComponent struct {
+	Symbol string `json:"symbol"`
+}
+
+// Construction represents the construction progress of a waypoint, such as a jump gate
+type Construction struct {
+	Symbol     string                 `json:"symbol"`
+	Materials  []ConstructionMaterial `json:"materials"`
+	IsComplete bool                   `json:"isComplete"`
+}
+
+// ConstructionMaterial represents a material required to complete construction
+type ConstructionMaterial struct {
+	TradeSymbol string `json:"tradeSymbol"`
+	Required    int    `json:"required"`
+	Fulfilled   int    `json:"fulfilled"`
+}
+
 // Faction represents a SpaceTraders faction
 type Faction struct {
 	Symbol       string         `json:"symbol"`
@@ -297,6 +374,76 @@ type FactionTrait struct {
 	Description string `json:"description"`
 }
 
+// Shipyard represents a shipyard at a waypoint
+type Shipyard struct {
+	Symbol           string                `json:"symbol"`
+	ShipTypes        []ShipyardShipType    `json:"shipTypes"`
+	Transactions     []ShipyardTransaction `json:"transactions,omitempty"`
+	Ships            []ShipyardShip        `json:"ships,omitempty"`
+	ModificationsFee int                   `json:"modificationsFee"`
+}
+
+// ShipyardShipType represents a type of ship sold at a shipyard
+type ShipyardShipType struct {
+	Type string `json:"type"`
+}
+
+// ShipyardShip represents a ship available for purchase at a shipyard
+type ShipyardShip struct {
+	Type          string           `json:"type"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	Supply        string           `json:"supply"`
+	Activity      string           `json:"activity,omitempty"`
+	PurchasePrice int              `json:"purchasePrice"`
+	Frame         Frame            `json:"frame"`
+	Reactor       Reactor          `json:"reactor"`
+	Engine        Engine           `json:"engine"`
+	Modules       []Module         `json:"modules"`
+	Mounts        []Mount          `json:"mounts"`
+	Crew          ShipyardShipCrew `json:"crew"`
+}
+
+// ShipyardShipCrew represents the crew requirements of a shipyard ship
+type ShipyardShipCrew struct {
+	Required int `json:"required"`
+	Capacity int `json:"capacity"`
+}
+
+// ShipyardTransaction represents a ship purchase at a shipyard
+type ShipyardTransaction struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ShipType       string    `json:"shipType"`
+	Price          int       `json:"price"`
+	AgentSymbol    string    `json:"agentSymbol"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// ShipModificationTransaction represents the cost of installing or removing a ship component
+type ShipModificationTransaction struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ShipSymbol     string    `json:"shipSymbol"`
+	TradeSymbol    string    `json:"tradeSymbol"`
+	TotalPrice     int       `json:"totalPrice"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// RepairTransaction represents the cost of repairing a ship
+type RepairTransaction struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ShipSymbol     string    `json:"shipSymbol"`
+	TotalPrice     int       `json:"totalPrice"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// ScrapTransaction represents the value received for scrapping a ship
+type ScrapTransaction struct {
+	WaypointSymbol string    `json:"waypointSymbol"`
+	ShipSymbol     string    `json:"shipSymbol"`
+	TotalPrice     int       `json:"totalPrice"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
 // Survey represents a mining survey
 type Survey struct {
 	Signature  string          `json:"signature"`
@@ -323,10 +470,88 @@ type ExtractionYield struct {
 	Units  int    `json:"units"`
 }
 
-// APIResponse represents a standard API response wrapper
-type APIResponse struct {
-	Data interface{} `json:"data"`
-	Meta *Meta       `json:"meta,omitempty"`
+// Siphon represents a gas siphoning result
+type Siphon struct {
+	ShipSymbol string      `json:"shipSymbol"`
+	Yield      SiphonYield `json:"yield"`
+}
+
+// SiphonYield represents the yield from siphoning
+type SiphonYield struct {
+	Symbol string `json:"symbol"`
+	Units  int    `json:"units"`
+}
+
+// Cooldown represents the cooldown of a ship's reactor after an action
+type Cooldown struct {
+	ShipSymbol       string     `json:"shipSymbol"`
+	TotalSeconds     int        `json:"totalSeconds"`
+	RemainingSeconds int        `json:"remainingSeconds"`
+	Expiration       *time.Time `json:"expiration,omitempty"`
+}
+
+// ServerStatus represents the status of the game server, returned by GET /
+type ServerStatus struct {
+	Status        string         `json:"status"`
+	Version       string         `json:"version"`
+	ResetDate     string         `json:"resetDate"` // Date of the last reset, e.g. 2026-10-11
+	Description   string         `json:"description"`
+	Stats         ServerStats    `json:"stats"`
+	Leaderboards  Leaderboards   `json:"leaderboards"`
+	ServerResets  ServerResets   `json:"serverResets"`
+	Announcements []Announcement `json:"announcements"`
+	Links         []ServerLink   `json:"links"`
+}
+
+// ServerStats represents universe-wide statistics
+type ServerStats struct {
+	Accounts  int `json:"accounts,omitempty"`
+	Agents    int `json:"agents"`
+	Ships     int `json:"ships"`
+	Systems   int `json:"systems"`
+	Waypoints int `json:"waypoints"`
+}
+
+// Leaderboards represents the current agent leaderboards
+type Leaderboards struct {
+	MostCredits         []CreditsLeader `json:"mostCredits"`
+	MostSubmittedCharts []ChartsLeader  `json:"mostSubmittedCharts"`
+}
+
+// CreditsLeader represents an agent's entry on the credits leaderboard
+type CreditsLeader struct {
+	AgentSymbol string `json:"agentSymbol"`
+	Credits     int64  `json:"credits"`
+}
+
+// ChartsLeader represents an agent's entry on the submitted charts leaderboard
+type ChartsLeader struct {
+	AgentSymbol string `json:"agentSymbol"`
+	ChartCount  int    `json:"chartCount"`
+}
+
+// ServerResets represents the server reset schedule
+type ServerResets struct {
+	Next      time.Time `json:"next"`
+	Frequency string    `json:"frequency"`
+}
+
+// Announcement represents a server announcement
+type Announcement struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ServerLink represents a link published by the server
+type ServerLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIResponse represents a standard API response wrapper with typed data
+type APIResponse[T any] struct {
+	Data T     `json:"data"`
+	Meta *Meta `json:"meta,omitempty"`
 }
 
 // Meta represents pagination and response metadata
@@ -361,6 +586,235 @@ type RegisterAgentResponse struct {
 	Token    string   `json:"token"`
 }
 
+// Contract request/response types
+
+// DeliverContractRequest represents a request to deliver cargo for a contract
+type DeliverContractRequest struct {
+	ShipSymbol  string `json:"shipSymbol"`
+	TradeSymbol string `json:"tradeSymbol"`
+	Units       int    `json:"units"`
+}
+
+// AcceptContractResponse represents the response from accepting a contract
+type AcceptContractResponse struct {
+	Agent    Agent    `json:"agent"`
+	Contract Contract `json:"contract"`
+}
+
+// DeliverContractResponse represents the response from delivering contract cargo
+type DeliverContractResponse struct {
+	Contract Contract `json:"contract"`
+	Cargo    Cargo    `json:"cargo"`
+}
+
+// FulfillContractResponse represents the response from fulfilling a contract
+type FulfillContractResponse struct {
+	Agent    Agent    `json:"agent"`
+	Contract Contract `json:"contract"`
+}
+
+// NegotiateContractResponse represents the response from negotiating a new contract
+type NegotiateContractResponse struct {
+	Contract Contract `json:"contract"`
+}
+
+// Cargo management request/response types
+
+// JettisonCargoRequest represents a request to jettison cargo from a ship
+type JettisonCargoRequest struct {
+	Symbol string `json:"symbol"`
+	Units  int    `json:"units"`
+}
+
+// JettisonCargoResponse represents the response from jettisoning cargo
+type JettisonCargoResponse struct {
+	Cargo Cargo `json:"cargo"`
+}
+
+// TransferCargoRequest represents a request to transfer cargo to another ship
+type TransferCargoRequest struct {
+	TradeSymbol string `json:"tradeSymbol"`
+	Units       int    `json:"units"`
+	ShipSymbol  string `json:"shipSymbol"` // Receiving ship
+}
+
+// TransferCargoResponse represents the response from transferring cargo
+type TransferCargoResponse struct {
+	Cargo       Cargo  `json:"cargo"`
+	TargetCargo *Cargo `json:"targetCargo,omitempty"`
+}
+
+// RefineCargoRequest represents a request to refine cargo into a good
+type RefineCargoRequest struct {
+	Produce string `json:"produce"` // Good to produce, e.g. IRON
+}
+
+// RefineCargoResponse represents the response from refining cargo
+type RefineCargoResponse struct {
+	Cargo    Cargo        `json:"cargo"`
+	Cooldown Cooldown     `json:"cooldown"`
+	Produced []RefineGood `json:"produced"`
+	Consumed []RefineGood `json:"consumed"`
+}
+
+// RefineGood represents a good produced or consumed by refining
+type RefineGood struct {
+	TradeSymbol string `json:"tradeSymbol"`
+	Units       int    `json:"units"`
+}
+
+// Inter-system travel request/response types
+
+// JumpShipRequest represents a request to jump a ship to another system's jump gate
+type JumpShipRequest struct {
+	WaypointSymbol string `json:"waypointSymbol"`
+}
+
+// JumpShipResponse represents the response from jumping a ship. The
+// transaction records the antimatter consumed by the jump.
+type JumpShipResponse struct {
+	Nav         Navigation  `json:"nav"`
+	Cooldown    Cooldown    `json:"cooldown"`
+	Transaction Transaction `json:"transaction"`
+	Agent       Agent       `json:"agent"`
+}
+
+// WarpShipRequest represents a request to warp a ship to a waypoint in another system
+type WarpShipRequest struct {
+	WaypointSymbol string `json:"waypointSymbol"`
+}
+
+// Shipyard request/response types
+
+// PurchaseShipRequest represents a request to purchase a ship
+type PurchaseShipRequest struct {
+	ShipType       string `json:"shipType"`
+	WaypointSymbol string `json:"waypointSymbol"`
+}
+
+// PurchaseShipResponse represents the response from purchasing a ship
+type PurchaseShipResponse struct {
+	Agent       Agent               `json:"agent"`
+	Ship        Ship                `json:"ship"`
+	Transaction ShipyardTransaction `json:"transaction"`
+}
+
+// Mining request/response types
+
+// CreateSurveyResponse represents the response from surveying a waypoint
+type CreateSurveyResponse struct {
+	Cooldown Cooldown `json:"cooldown"`
+	Surveys  []Survey `json:"surveys"`
+}
+
+// ExtractResourcesResponse represents the response from extracting resources
+type ExtractResourcesResponse struct {
+	Cooldown   Cooldown             `json:"cooldown"`
+	Extraction Extraction           `json:"extraction"`
+	Cargo      Cargo                `json:"cargo"`
+	Events     []ShipConditionEvent `json:"events,omitempty"`
+}
+
+// SiphonResourcesResponse represents the response from siphoning gas
+type SiphonResourcesResponse struct {
+	Cooldown Cooldown             `json:"cooldown"`
+	Siphon   Siphon               `json:"siphon"`
+	Cargo    Cargo                `json:"cargo"`
+	Events   []ShipConditionEvent `json:"events,omitempty"`
+}
+
+// Ship outfitting request/response types
+
+// ShipMountRequest represents a request to install or remove a ship mount
+type ShipMountRequest struct {
+	Symbol string `json:"symbol"`
+}
+
+// ShipModuleRequest represents a request to install or remove a ship module
+type ShipModuleRequest struct {
+	Symbol string `json:"symbol"`
+}
+
+// ShipMountsResponse represents the response from installing or removing a mount
+type ShipMountsResponse struct {
+	Agent       Agent                       `json:"agent"`
+	Mounts      []Mount                     `json:"mounts"`
+	Cargo       Cargo                       `json:"cargo"`
+	Transaction ShipModificationTransaction `json:"transaction"`
+}
+
+// ShipModulesResponse represents the response from installing or removing a module
+type ShipModulesResponse struct {
+	Agent       Agent                       `json:"agent"`
+	Modules     []Module                    `json:"modules"`
+	Cargo       Cargo                       `json:"cargo"`
+	Transaction ShipModificationTransaction `json:"transaction"`
+}
+
+// RepairQuoteResponse represents the quoted cost of repairing a ship
+type RepairQuoteResponse struct {
+	Transaction RepairTransaction `json:"transaction"`
+}
+
+// RepairShipResponse represents the response from repairing a ship
+type RepairShipResponse struct {
+	Agent       Agent             `json:"agent"`
+	Ship        Ship              `json:"ship"`
+	Transaction RepairTransaction `json:"transaction"`
+}
+
+// ScrapQuoteResponse represents the quoted value of scrapping a ship
+type ScrapQuoteResponse struct {
+	Transaction ScrapTransaction `json:"transaction"`
+}
+
+// ScrapShipResponse represents the response from scrapping a ship
+type ScrapShipResponse struct {
+	Agent       Agent            `json:"agent"`
+	Transaction ScrapTransaction `json:"transaction"`
+}
+
+// Construction request/response types
+
+// SupplyConstructionRequest represents a request to supply materials to a construction site
+type SupplyConstructionRequest struct {
+	ShipSymbol  string `json:"shipSymbol"`
+	TradeSymbol string `json:"tradeSymbol"`
+	Units       int    `json:"units"`
+}
+
+// SupplyConstructionResponse represents the response from supplying a construction site
+type SupplyConstructionResponse struct {
+	Construction Construction `json:"construction"`
+	Cargo        Cargo        `json:"cargo"`
+}
+
+// Scanning and charting response types
+
+// CreateChartResponse represents the response from charting a waypoint
+type CreateChartResponse struct {
+	Chart    Chart    `json:"chart"`
+	Waypoint Waypoint `json:"waypoint"`
+}
+
+// ScanSystemsResponse represents the response from scanning for systems
+type ScanSystemsResponse struct {
+	Cooldown Cooldown        `json:"cooldown"`
+	Systems  []ScannedSystem `json:"systems"`
+}
+
+// ScanWaypointsResponse represents the response from scanning for waypoints
+type ScanWaypointsResponse struct {
+	Cooldown  Cooldown          `json:"cooldown"`
+	Waypoints []ScannedWaypoint `json:"waypoints"`
+}
+
+// ScanShipsResponse represents the response from scanning for ships
+type ScanShipsResponse struct {
+	Cooldown Cooldown      `json:"cooldown"`
+	Ships    []ScannedShip `json:"ships"`
+}
+
 // Common request types
 
 // PaginationOptions represents pagination query parameters
@@ -369,11 +823,35 @@ type PaginationOptions struct {
 	Limit *int `json:"limit,omitempty"`
 }
 
+// WaypointFilterOptions represents query parameters for listing waypoints
+type WaypointFilterOptions struct {
+	PaginationOptions
+	Type   string   `json:"type,omitempty"`   // Waypoint type, e.g. ASTEROID
+	Traits []string `json:"traits,omitempty"` // Waypoint traits, e.g. MARKETPLACE or SHIPYARD
+}
+
 // NavigateShipRequest represents a request to navigate a ship
 type NavigateShipRequest struct {
 	WaypointSymbol string `json:"waypointSymbol"`
 }
 
+// NavigateResult represents the ship state returned by navigation actions
+type NavigateResult struct {
+	Nav    Navigation           `json:"nav"`
+	Fuel   Fuel                 `json:"fuel"`
+	Events []ShipConditionEvent `json:"events,omitempty"`
+}
+
+// ShipNavResponse represents the response from orbiting or docking a ship
+type ShipNavResponse struct {
+	Nav Navigation `json:"nav"`
+}
+
+// SetFlightModeRequest represents a request to change a ship's flight mode
+type SetFlightModeRequest struct {
+	FlightMode string `json:"flightMode"`
+}
+
 // PurchaseCargoRequest represents a request to purchase cargo
 type PurchaseCargoRequest struct {
 	Symbol string `json:"symbol"`
@@ -9,10 +9,14 @@ import (
 	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
 	"io"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 )
 
@@ -26,7 +30,11 @@ const (
 type HTTPClient struct {
 	baseURL     string
 	httpClient  *http.Client
-	rateLimiter *ratelimit.TokenBucket
+	ownsPool    bool // Whether httpClient and its transport were built here
+	rateLimiter ratelimit.Limiter
+	fairQueue   *ratelimit.FairQueue // Set when FairShare is enabled
+	retryPolicy *RetryPolicy
+	handler     Handler // send wrapped in the configured middleware
 	token       string
 	userAgent   string
 }
@@ -36,7 +44,32 @@ type Config struct {
 	BaseURL     string
 	Timeout     time.Duration
 	UserAgent   string
-	RateLimiter *ratelimit.TokenBucket
+	RateLimiter Limiter
+	RetryPolicy *RetryPolicy // Optional: nil disables retries
+	Middleware  []Middleware // Optional: applied in order, the first being outermost
+
+	// FairShare shares the rate limiter between the keys attached with
+	// WithRateLimitKey, e.g. one per ship, in weighted round-robin order.
+	// Priorities still apply: keys take turns among the requests of the
+	// highest priority waiting.
+	FairShare bool
+
+	// HTTPClient is used as-is when set; Timeout, Transport and the
+	// connection settings below are then ignored
+	HTTPClient *http.Client
+	// Transport is used for requests when set, e.g. to configure proxies or
+	// TLS; the connection settings below are then ignored. A caller's client
+	// or transport may be shared, so its idle connections are never closed.
+	Transport http.RoundTripper
+
+	// Connection pool settings for the default transport; zero values keep
+	// the net/http defaults
+	MaxIdleConns        int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost int           // Idle connections kept per host
+	MaxConnsPerHost     int           // Total connections per host; zero means no limit
+	IdleConnTimeout     time.Duration // How long an idle connection is kept
+	KeepAlive           time.Duration // TCP keep-alive probe interval; negative disables probes
+	DisableKeepAlives   bool          // Use a new connection for every request
 }
 
 // DefaultConfig returns a default HTTP client configuration
@@ -46,7 +79,101 @@ func DefaultConfig() *Config {
 		Timeout:     DefaultTimeout,
 		UserAgent:   UserAgent,
 		RateLimiter: ratelimit.NewTokenBucket(),
+		RetryPolicy: DefaultRetryPolicy(),
+
+		// Bots send most requests to the single API host, so keep more than
+		// net/http's default of 2 idle connections around for it
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		KeepAlive:           30 * time.Second,
+	}
+}
+
+// RetryPolicy configures automatic retries of failed requests. Rate limited
+// (429) requests are retried for any method, since the server rejected them
+// without processing. 502, 503 and 504 responses and network errors are only
+// retried for idempotent requests: GET, HEAD, OPTIONS, PUT, DELETE, or any
+// request with Idempotent set.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first; 1 or less disables retries
+	InitialBackoff time.Duration // Delay before the first retry; defaults to 500ms
+	MaxBackoff     time.Duration // Upper bound on the computed backoff; zero means no bound
+	Multiplier     float64       // Backoff growth per attempt; defaults to 2
+	Jitter         float64       // Fraction of the backoff randomly subtracted, from 0 to 1
+
+	// OnRetry is called before waiting to retry a request
+	OnRetry func(event RetryEvent)
+}
+
+// RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	Request    *Request
+	Attempt    int           // The attempt that failed, starting at 1
+	Delay      time.Duration // Time until the next attempt
+	StatusCode int           // Status code of the failed attempt, or 0 for network errors
+	Err        error
+}
+
+// Handler executes a request, returning the response and any transport or API error
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to observe or alter requests and responses, in
+// the manner of an http.RoundTripper. Middleware runs once per attempt, after
+// the rate limiter, and may modify req or short-circuit the chain by
+// returning without calling next, e.g. to inject faults.
+type Middleware func(next Handler) Handler
+
+// Hooks returns a Middleware that calls before ahead of each attempt and
+// after with its outcome. Either hook may be nil.
+func Hooks(before func(ctx context.Context, req *Request), after func(ctx context.Context, req *Request, resp *Response, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if before != nil {
+				before(ctx, req)
+			}
+
+			resp, err := next(ctx, req)
+
+			if after != nil {
+				after(ctx, req, resp, err)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// DefaultRetryPolicy returns the retry policy used by DefaultConfig
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// backoff returns the jittered exponential delay before retrying after the given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
 	}
+	if p.Jitter > 0 {
+		delay -= delay * math.Min(p.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(delay)
 }
 
 // NewHTTPClient creates a new HTTP client
@@ -55,16 +182,77 @@ func NewHTTPClient(config *Config) *HTTPClient {
 		config = DefaultConfig()
 	}
 
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
+	httpClient := config.HTTPClient
+	ownsPool := false
+	if httpClient == nil {
+		roundTripper := config.Transport
+		if roundTripper == nil {
+			roundTripper = newTransport(config)
+			ownsPool = true
+		}
+
+		httpClient = &http.Client{
+			Timeout:   config.Timeout,
+			Transport: roundTripper,
+		}
 	}
 
-	return &HTTPClient{
+	c := &HTTPClient{
 		baseURL:     strings.TrimRight(config.BaseURL, "/"),
 		httpClient:  httpClient,
+		ownsPool:    ownsPool,
 		rateLimiter: config.RateLimiter,
+		retryPolicy: config.RetryPolicy,
 		userAgent:   config.UserAgent,
 	}
+	if config.FairShare {
+		c.fairQueue = ratelimit.NewFairQueue(config.RateLimiter)
+	}
+
+	c.handler = c.send
+	for i := len(config.Middleware) - 1; i >= 0; i-- {
+		c.handler = config.Middleware[i](c.handler)
+	}
+
+	return c
+}
+
+// newTransport builds an http.Transport from the default one with the configured connection settings
+func newTransport(config *Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.KeepAlive != 0 {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: config.KeepAlive,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	transport.DisableKeepAlives = config.DisableKeepAlives
+
+	return transport
+}
+
+// CloseIdleConnections closes any idle keep-alive connections held by the
+// underlying HTTP client. A client or transport passed in the Config belongs
+// to the caller and may be shared, so its connections are left alone.
+func (c *HTTPClient) CloseIdleConnections() {
+	if !c.ownsPool {
+		return
+	}
+	c.httpClient.CloseIdleConnections()
 }
 
 // SetToken sets the authentication token
@@ -82,8 +270,18 @@ type Request struct {
 	Method      string
 	Path        string
 	Body        interface{}
-	QueryParams map[string]string
+	QueryParams url.Values // Supports repeated keys, e.g. traits=A&traits=B
 	Headers     map[string]string
+	Idempotent  bool // Marks a request whose method is not idempotent as safe to retry
+}
+
+// isIdempotent reports whether the request may be safely repeated after a transient failure
+func (r *Request) isIdempotent() bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.Idempotent
 }
 
 // Response represents an HTTP response
@@ -91,15 +289,301 @@ type Response struct {
 	StatusCode int
 	Headers    http.Header
 	Body       []byte
+	Latency    time.Duration // Round trip time, excluding any rate limiter wait
+}
+
+// IsNoContent returns true if the response carries no body, e.g. a 204 No Content
+func (r *Response) IsNoContent() bool {
+	return r.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(r.Body)) == 0
+}
+
+// RequestID returns the request ID assigned by the server, if any
+func (r *Response) RequestID() string {
+	return r.Headers.Get("x-request-id")
 }
 
-// Do executes an HTTP request with rate limiting
+// RateLimit returns the rate limit state reported in the response headers
+func (r *Response) RateLimit() RateLimitInfo {
+	return RateLimitInfo{
+		Type:           r.Headers.Get("x-ratelimit-type"),
+		Limit:          parseIntHeader(r.Headers.Get("x-ratelimit-limit")),
+		LimitBurst:     parseIntHeader(r.Headers.Get("x-ratelimit-limit-burst")),
+		LimitPerSecond: parseIntHeader(r.Headers.Get("x-ratelimit-limit-per-second")),
+		Remaining:      parseIntHeader(r.Headers.Get("x-ratelimit-remaining")),
+		Reset:          parseTimeHeader(r.Headers.Get("x-ratelimit-reset")),
+	}
+}
+
+// RateLimitInfo represents the rate limit state reported by the server
+type RateLimitInfo struct {
+	Type           string    `json:"type"`
+	Limit          int       `json:"limit"`
+	LimitBurst     int       `json:"limit_burst"`
+	LimitPerSecond int       `json:"limit_per_second"`
+	Remaining      int       `json:"remaining"`
+	Reset          time.Time `json:"reset"`
+}
+
+// ResponseInfo describes the HTTP response behind an API call
+type ResponseInfo struct {
+	StatusCode int           `json:"status_code"`
+	Headers    http.Header   `json:"headers"`
+	RequestID  string        `json:"request_id"`
+	Latency    time.Duration `json:"latency"`
+	RateLimit  RateLimitInfo `json:"rate_limit"`
+	Meta       *schema.Meta  `json:"meta,omitempty"` // Pagination metadata, set by list endpoints
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a context that records the response of each
+// request made with it into info. If a call makes several requests, info
+// describes the last one.
+func WithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+// recordResponseInfo fills the ResponseInfo attached to ctx, if any
+func recordResponseInfo(ctx context.Context, resp *Response) {
+	info, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	if !ok || info == nil {
+		return
+	}
+
+	*info = ResponseInfo{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		RequestID:  resp.RequestID(),
+		Latency:    resp.Latency,
+		RateLimit:  resp.RateLimit(),
+	}
+
+	if !resp.IsNoContent() {
+		var envelope struct {
+			Meta *schema.Meta `json:"meta"`
+		}
+		if err := json.Unmarshal(resp.Body, &envelope); err == nil {
+			info.Meta = envelope.Meta
+		}
+	}
+}
+
+// Limiter is the rate limiter the client waits on before each request,
+// e.g. a per-process token bucket or one shared between processes
+type Limiter = ratelimit.Limiter
+
+// BucketState describes the state of a rate limiter
+type BucketState = ratelimit.BucketState
+
+// NewSharedLimiter returns a rate limiter whose budget is shared by every
+// process on this host using the same state file, such as several bots
+// running under one agent token
+func NewSharedLimiter(path string) (Limiter, error) {
+	return ratelimit.NewFileLimiter(path)
+}
+
+// NewSharedDualWindowLimiter returns a rate limiter like NewDualWindowLimiter
+// whose budget is shared by every process on this host using the same
+// state file
+func NewSharedDualWindowLimiter(path string) (Limiter, error) {
+	return ratelimit.NewDualWindowFileLimiter(path)
+}
+
+// NewDualWindowLimiter returns a rate limiter modelling the SpaceTraders
+// policy exactly: 2 requests per second, plus a 30 request burst pool that
+// refills over a 60 second window
+func NewDualWindowLimiter() Limiter {
+	return ratelimit.NewDualWindowLimiter()
+}
+
+// Reservation holds requests claimed from the rate limiter ahead of time
+type Reservation = ratelimit.Reservation
+
+// WithReservation returns a context whose requests spend the requests held
+// by r rather than waiting on the rate limiter again
+func WithReservation(ctx context.Context, r *Reservation) context.Context {
+	return ratelimit.WithReservation(ctx, r)
+}
+
+// ErrExceedsCapacity is returned when reserving more requests than the rate
+// limiter can ever provide at once
+var ErrExceedsCapacity = ratelimit.ErrExceedsCapacity
+
+// ErrInvalidReservation is returned when reserving fewer than one request
+var ErrInvalidReservation = ratelimit.ErrInvalidReservation
+
+// Priority orders requests waiting for the rate limiter
+type Priority = ratelimit.Priority
+
+// Request priorities; requests without one use PriorityNormal
+const (
+	PriorityLow    = ratelimit.PriorityLow
+	PriorityNormal = ratelimit.PriorityNormal
+	PriorityHigh   = ratelimit.PriorityHigh
+)
+
+// WithPriority returns a context whose requests wait for the rate limiter
+// with priority p. Higher priority requests are sent first, requests of
+// equal priority in arrival order, and long-waiting requests are gradually
+// promoted so that low priority work still progresses.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return ratelimit.WithPriority(ctx, p)
+}
+
+// WithRateLimitKey returns a context whose requests share the rate limiter
+// fairly with other keys when FairShare is enabled, typically keyed by the
+// symbol of the ship making the requests
+func WithRateLimitKey(ctx context.Context, key string) context.Context {
+	return ratelimit.WithKey(ctx, key)
+}
+
+// KeyStats describes how long the requests of one rate limit key waited
+type KeyStats = ratelimit.KeyStats
+
+// Do executes an HTTP request with rate limiting, retrying transient
+// failures according to the client's retry policy
 func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
-	// Wait for rate limiter
-	if err := c.rateLimiter.Wait(ctx); err != nil {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, req)
+
+		delay, retry := c.retryDelay(ctx, req, attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		if c.retryPolicy.OnRetry != nil {
+			event := RetryEvent{
+				Request: req,
+				Attempt: attempt,
+				Delay:   delay,
+				Err:     err,
+			}
+			if resp != nil {
+				event.StatusCode = resp.StatusCode
+			}
+			c.retryPolicy.OnRetry(event)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay decides whether a failed attempt should be retried and how long to wait first
+func (c *HTTPClient) retryDelay(ctx context.Context, req *Request, attempt int, resp *Response, err error) (time.Duration, bool) {
+	if err == nil || c.retryPolicy == nil || attempt >= c.retryPolicy.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	// Prefer the server's own hint about when the limit resets
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > 0 {
+			return rateLimitErr.RetryAfter, true
+		}
+		if wait := time.Until(rateLimitErr.Reset); !rateLimitErr.Reset.IsZero() && wait > 0 {
+			return wait, true
+		}
+		return c.retryPolicy.backoff(attempt), true
+	}
+
+	if !req.isIdempotent() {
+		return 0, false
+	}
+
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			if seconds := parseIntHeader(resp.Headers.Get("Retry-After")); seconds > 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+			return c.retryPolicy.backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	if isTransientError(err) {
+		return c.retryPolicy.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// isTransientError reports whether err is a network failure worth retrying,
+// such as a timeout, a connection reset or a truncated response. Every error
+// from http.Client.Do is a net.Error, so permanent failures such as an
+// unsupported scheme or an invalid certificate must not be matched by type.
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// do performs a single rate limited attempt of a request through the middleware chain
+func (c *HTTPClient) do(ctx context.Context, req *Request) (*Response, error) {
+	if err := c.waitRateLimit(ctx); err != nil {
 		return nil, fmt.Errorf("rate limiter cancelled: %w", err)
 	}
 
+	resp, err := c.handler(ctx, req)
+	if resp != nil {
+		recordResponseInfo(ctx, resp)
+		c.syncRateLimiter(resp, err)
+	}
+
+	return resp, err
+}
+
+// waitRateLimit waits for the rate limiter, spending a reserved request
+// instead when the context carries a reservation with requests left
+func (c *HTTPClient) waitRateLimit(ctx context.Context) error {
+	if reservation := ratelimit.ReservationFromContext(ctx); reservation != nil {
+		if used, err := reservation.Use(ctx); used {
+			return err
+		}
+	}
+
+	// Wait in turn with other keys when sharing fairly
+	if c.fairQueue != nil {
+		return c.fairQueue.Wait(ctx)
+	}
+	return c.rateLimiter.Wait(ctx)
+}
+
+// syncRateLimiter corrects the local rate limiter from the rate limit state
+// reported by the server, which also counts requests made by other clients
+// sharing the same token or IP address
+func (c *HTTPClient) syncRateLimiter(resp *Response, err error) {
+	if resp.Headers.Get("x-ratelimit-remaining") != "" {
+		c.rateLimiter.SetRemaining(resp.RateLimit().Remaining)
+	}
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return
+	}
+
+	c.rateLimiter.SetRemaining(0)
+	if rateLimitErr.RetryAfter > 0 {
+		c.rateLimiter.PauseUntil(time.Now().Add(rateLimitErr.RetryAfter))
+	} else if !rateLimitErr.Reset.IsZero() {
+		c.rateLimiter.PauseUntil(rateLimitErr.Reset)
+	}
+}
+
+// send executes a request over HTTP; it is the innermost Handler of the middleware chain
+func (c *HTTPClient) send(ctx context.Context, req *Request) (*Response, error) {
 	// Build HTTP request
 	httpReq, err := c.buildRequest(ctx, req)
 	if err != nil {
@@ -107,6 +591,7 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 	}
 
 	// Execute request
+	start := time.Now()
 	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
@@ -123,6 +608,7 @@ func (c *HTTPClient) Do(ctx context.Context, req *Request) (*Response, error) {
 		StatusCode: httpResp.StatusCode,
 		Headers:    httpResp.Header,
 		Body:       body,
+		Latency:    time.Since(start),
 	}
 
 	// Handle rate limit responses
@@ -145,11 +631,7 @@ func (c *HTTPClient) buildRequest(ctx context.Context, req *Request) (*http.Requ
 
 	// Add query parameters
 	if len(req.QueryParams) > 0 {
-		params := url.Values{}
-		for k, v := range req.QueryParams {
-			params.Add(k, v)
-		}
-		requestURL += "?" + params.Encode()
+		requestURL += "?" + req.QueryParams.Encode()
 	}
 
 	// Prepare request body
@@ -210,8 +692,14 @@ func (c *HTTPClient) handleRateLimitResponse(resp *http.Response) error {
 
 // parseAPIError parses API error responses
 func (c *HTTPClient) parseAPIError(body []byte, statusCode int) error {
+	// The API wraps errors as {"error": {...}}; fall back to a bare error object
+	var envelope struct {
+		Error *schema.APIError `json:"error"`
+	}
 	var apiError schema.APIError
-	if err := json.Unmarshal(body, &apiError); err != nil {
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		apiError = *envelope.Error
+	} else if err := json.Unmarshal(body, &apiError); err != nil {
 		// If we can't parse the error, return a generic one
 		return &APIError{
 			StatusCode: statusCode,
@@ -219,12 +707,32 @@ func (c *HTTPClient) parseAPIError(body []byte, statusCode int) error {
 		}
 	}
 
-	return &APIError{
+	return classifyAPIError(&APIError{
 		StatusCode: statusCode,
 		Message:    apiError.Message,
 		Code:       apiError.Code,
 		Data:       apiError.Data,
+	})
+}
+
+// classifyAPIError wraps API errors with known error codes in their typed error
+func classifyAPIError(apiErr *APIError) error {
+	switch apiErr.Code {
+	case ErrorCodeCooldownConflict:
+		cooldownErr := &CooldownError{APIError: apiErr}
+		if data, ok := apiErr.Data["cooldown"]; ok {
+			if jsonData, err := json.Marshal(data); err == nil {
+				json.Unmarshal(jsonData, &cooldownErr.Cooldown)
+			}
+		}
+		return cooldownErr
+	case ErrorCodeSurveyExpired:
+		return &SurveyExpiredError{APIError: apiErr}
+	case ErrorCodeSurveyExhausted:
+		return &SurveyExhaustedError{APIError: apiErr}
 	}
+
+	return apiErr
 }
 
 // GetRateLimiterState returns the current state of the rate limiter
@@ -232,6 +740,45 @@ func (c *HTTPClient) GetRateLimiterState() ratelimit.BucketState {
 	return c.rateLimiter.GetState()
 }
 
+// SetRateLimitWeight sets how many consecutive requests key may send per
+// round-robin turn when FairShare is enabled
+func (c *HTTPClient) SetRateLimitWeight(key string, weight int) {
+	if c.fairQueue != nil {
+		c.fairQueue.SetWeight(key, weight)
+	}
+}
+
+// GetRateLimitKeyStats returns the rate limiter wait statistics of each key,
+// or nil when FairShare is disabled
+func (c *HTTPClient) GetRateLimitKeyStats() map[string]KeyStats {
+	if c.fairQueue == nil {
+		return nil
+	}
+	return c.fairQueue.Stats()
+}
+
+// Reserve claims n requests from the rate limiter and reports when they
+// may all be sent, so that a sequence of requests can run back-to-back.
+// Requests made with a context from WithReservation spend the reserved
+// requests; cancelling the reservation returns any that are left.
+func (c *HTTPClient) Reserve(n int) (*Reservation, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("cannot reserve %d requests: %w", n, ErrInvalidReservation)
+	}
+
+	reserver, ok := c.rateLimiter.(ratelimit.Reserver)
+	if !ok {
+		return nil, fmt.Errorf("rate limiter %T does not support reservations", c.rateLimiter)
+	}
+
+	reservation := reserver.Reserve(n)
+	if !reservation.OK() {
+		return nil, fmt.Errorf("cannot reserve %d requests: %w", n, ErrExceedsCapacity)
+	}
+
+	return reservation, nil
+}
+
 // ResetRateLimiter resets the rate limiter to full capacity
 func (c *HTTPClient) ResetRateLimiter() {
 	c.rateLimiter.Reset()
@@ -304,6 +851,59 @@ func IsAPIError(err error) bool {
 	return errors.As(err, &apiErr)
 }
 
+// SpaceTraders API error codes with dedicated error types
+const (
+	ErrorCodeCooldownConflict = 4000
+	ErrorCodeSurveyExpired    = 4221
+	ErrorCodeSurveyExhausted  = 4224
+)
+
+// CooldownError is returned when an action is rejected because the ship is on cooldown
+type CooldownError struct {
+	*APIError
+	Cooldown schema.Cooldown `json:"cooldown"`
+}
+
+func (e *CooldownError) Unwrap() error {
+	return e.APIError
+}
+
+// IsCooldownError returns true if the error is a ship cooldown error
+func IsCooldownError(err error) bool {
+	var cooldownErr *CooldownError
+	return errors.As(err, &cooldownErr)
+}
+
+// SurveyExpiredError is returned when extracting with a survey that has expired
+type SurveyExpiredError struct {
+	*APIError
+}
+
+func (e *SurveyExpiredError) Unwrap() error {
+	return e.APIError
+}
+
+// IsSurveyExpiredError returns true if the error is a survey expiration error
+func IsSurveyExpiredError(err error) bool {
+	var surveyErr *SurveyExpiredError
+	return errors.As(err, &surveyErr)
+}
+
+// SurveyExhaustedError is returned when extracting with a survey whose deposits are exhausted
+type SurveyExhaustedError struct {
+	*APIError
+}
+
+func (e *SurveyExhaustedError) Unwrap() error {
+	return e.APIError
+}
+
+// IsSurveyExhaustedError returns true if the error is a survey exhaustion error
+func IsSurveyExhaustedError(err error) bool {
+	var surveyErr *SurveyExhaustedError
+	return errors.As(err, &surveyErr)
+}
+
 // IsAuthError returns true if the error is an authentication error
 func IsAuthError(err error) bool {
 	var apiErr *APIError
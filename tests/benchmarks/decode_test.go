@@ -0,0 +1,83 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+	"testing"
+)
+
+// systemPayload builds a GET /systems/{system} response body with the given number of waypoints
+func systemPayload(b *testing.B, waypoints int) []byte {
+	b.Helper()
+
+	submitter := "COSMIC"
+	system := schema.System{
+		Symbol:       "X1-BENCH",
+		SectorSymbol: "X1",
+		Type:         "RED_STAR",
+	}
+	for i := 0; i < waypoints; i++ {
+		system.Waypoints = append(system.Waypoints, schema.Waypoint{
+			Symbol:       fmt.Sprintf("X1-BENCH-%02d", i),
+			Type:         "PLANET",
+			SystemSymbol: "X1-BENCH",
+			X:            i * 7,
+			Y:            -i * 3,
+			Orbitals:     []schema.Orbital{{Symbol: fmt.Sprintf("X1-BENCH-%02dA", i)}},
+			Traits: []schema.Trait{
+				{Symbol: "MARKETPLACE", Name: "Marketplace", Description: "A thriving center of commerce"},
+				{Symbol: "SHIPYARD", Name: "Shipyard", Description: "A facility for building and selling ships"},
+			},
+			Chart: &schema.Chart{SubmittedBy: &submitter},
+		})
+	}
+
+	body, err := json.Marshal(schema.APIResponse[schema.System]{Data: system})
+	if err != nil {
+		b.Fatalf("Failed to marshal payload: %v", err)
+	}
+
+	return body
+}
+
+// BenchmarkDecodeSystem compares decoding a 20-waypoint system directly into
+// a typed envelope against the former approach of decoding into interface{}
+// and round-tripping the data through JSON again.
+func BenchmarkDecodeSystem(b *testing.B) {
+	body := systemPayload(b, 20)
+
+	b.Run("TypedEnvelope", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+
+		for i := 0; i < b.N; i++ {
+			var apiResp schema.APIResponse[schema.System]
+			if err := json.Unmarshal(body, &apiResp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("InterfaceRoundTrip", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+
+		for i := 0; i < b.N; i++ {
+			var apiResp schema.APIResponse[interface{}]
+			if err := json.Unmarshal(body, &apiResp); err != nil {
+				b.Fatal(err)
+			}
+
+			data, err := json.Marshal(apiResp.Data)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var system schema.System
+			if err := json.Unmarshal(data, &system); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -2,17 +2,20 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/mock"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
 	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
 	"testing"
 	"time"
 )
 
 func TestClientIntegration(t *testing.T) {
-	// Start mock server
+	// Start mock server; server-side rate limiting is only enabled by the rate limiting subtest
 	mockServer := mock.NewMockServer()
 	defer mockServer.Close()
+	mockServer.SetRateLimitEnabled(false)
 
 	// Create client with mock server URL
 	config := &client.Config{
@@ -40,6 +43,30 @@ func TestClientIntegration(t *testing.T) {
 		testFleetOperations(t, ctx, client)
 	})
 
+	t.Run("Contract Operations", func(t *testing.T) {
+		testContractOperations(t, ctx, client)
+	})
+
+	t.Run("System Operations", func(t *testing.T) {
+		testSystemOperations(t, ctx, client)
+	})
+
+	t.Run("Shipyard Operations", func(t *testing.T) {
+		testShipyardOperations(t, ctx, client)
+	})
+
+	t.Run("Siphon Operations", func(t *testing.T) {
+		testSiphonOperations(t, ctx, client)
+	})
+
+	t.Run("Faction Operations", func(t *testing.T) {
+		testFactionOperations(t, ctx, client)
+	})
+
+	t.Run("Server Status", func(t *testing.T) {
+		testServerStatus(t, ctx, client)
+	})
+
 	t.Run("Authentication", func(t *testing.T) {
 		testAuthentication(t, ctx, client, mockServer.GetURL())
 	})
@@ -152,6 +179,379 @@ func testFleetOperations(t *testing.T, ctx context.Context, client *client.Space
 	}
 }
 
+func testContractOperations(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	resp, err := client.RegisterAgent(ctx, "CONTRACT_TEST", "COSMIC")
+	if err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	contracts, err := client.GetContracts(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get contracts: %v", err)
+	}
+
+	if len(contracts) != 1 {
+		t.Fatalf("Expected 1 starting contract, got %d", len(contracts))
+	}
+
+	contract, err := client.GetContract(ctx, contracts[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get contract: %v", err)
+	}
+
+	if contract.ID != resp.Contract.ID {
+		t.Errorf("Expected contract '%s', got '%s'", resp.Contract.ID, contract.ID)
+	}
+
+	accepted, err := client.AcceptContract(ctx, contract.ID)
+	if err != nil {
+		t.Fatalf("Failed to accept contract: %v", err)
+	}
+
+	if !accepted.Contract.Accepted {
+		t.Error("Contract should be accepted")
+	}
+
+	expectedCredits := resp.Agent.Credits + int64(contract.Terms.Payment.OnAccepted)
+	if accepted.Agent.Credits != expectedCredits {
+		t.Errorf("Expected %d credits after accepting, got %d", expectedCredits, accepted.Agent.Credits)
+	}
+
+	// Accepting twice should fail
+	if _, err := client.AcceptContract(ctx, contract.ID); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error accepting contract twice, got: %v", err)
+	}
+
+	// The starting ship carries no cargo, so delivery should be rejected
+	deliver := contract.Terms.Deliver[0]
+	_, err = client.DeliverContract(ctx, contract.ID, resp.Ship.Symbol, deliver.TradeSymbol, 1)
+	if !transport.IsAPIError(err) {
+		t.Errorf("Expected API error delivering without cargo, got: %v", err)
+	}
+
+	// Terms are unmet, so fulfillment should be rejected
+	if _, err := client.FulfillContract(ctx, contract.ID); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error fulfilling unmet contract, got: %v", err)
+	}
+
+	// Only one active contract is allowed at a time
+	if _, err := client.NegotiateContract(ctx, resp.Ship.Symbol); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error negotiating with an active contract, got: %v", err)
+	}
+
+	// A fresh ship has no cooldown, which the API reports as 204 No Content
+	cooldown, err := client.GetShipCooldown(ctx, resp.Ship.Symbol)
+	if err != nil {
+		t.Fatalf("Failed to get ship cooldown: %v", err)
+	}
+
+	if cooldown != nil {
+		t.Errorf("Expected no cooldown, got %+v", cooldown)
+	}
+}
+
+func testSystemOperations(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	resp, err := client.RegisterAgent(ctx, "SYSTEM_TEST", "COSMIC")
+	if err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	systemSymbol := resp.Ship.Nav.SystemSymbol
+
+	systems, err := client.GetSystems(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get systems: %v", err)
+	}
+
+	if len(systems) == 0 {
+		t.Error("Expected at least one system")
+	}
+
+	system, err := client.GetSystem(ctx, systemSymbol)
+	if err != nil {
+		t.Fatalf("Failed to get system: %v", err)
+	}
+
+	if system.Symbol != systemSymbol {
+		t.Errorf("Expected system '%s', got '%s'", systemSymbol, system.Symbol)
+	}
+
+	waypoints, err := client.GetWaypoints(ctx, systemSymbol, nil)
+	if err != nil {
+		t.Fatalf("Failed to get waypoints: %v", err)
+	}
+
+	if len(waypoints) != len(system.Waypoints) {
+		t.Errorf("Expected %d waypoints, got %d", len(system.Waypoints), len(waypoints))
+	}
+
+	markets, err := client.GetWaypoints(ctx, systemSymbol, &schema.WaypointFilterOptions{
+		Traits: []string{"MARKETPLACE"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get waypoints by trait: %v", err)
+	}
+
+	for _, wp := range markets {
+		if !hasTrait(wp, "MARKETPLACE") {
+			t.Errorf("Waypoint %s does not have MARKETPLACE trait", wp.Symbol)
+		}
+	}
+
+	if len(markets) == 0 || len(markets) == len(waypoints) {
+		t.Fatalf("Expected trait filter to narrow results, got %d of %d", len(markets), len(waypoints))
+	}
+
+	none, err := client.GetWaypoints(ctx, systemSymbol, &schema.WaypointFilterOptions{
+		Traits: []string{"MARKETPLACE", "COMMON_METAL_DEPOSITS"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to get waypoints by traits: %v", err)
+	}
+
+	if len(none) != 0 {
+		t.Errorf("Expected no waypoint with both traits, got %d", len(none))
+	}
+
+	asteroids, err := client.GetWaypoints(ctx, systemSymbol, &schema.WaypointFilterOptions{
+		Type: "ASTEROID",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get waypoints by type: %v", err)
+	}
+
+	for _, wp := range asteroids {
+		if wp.Type != "ASTEROID" {
+			t.Errorf("Expected ASTEROID waypoint, got %s", wp.Type)
+		}
+	}
+
+	waypoint, err := client.GetWaypoint(ctx, systemSymbol, markets[0].Symbol)
+	if err != nil {
+		t.Fatalf("Failed to get waypoint: %v", err)
+	}
+
+	if waypoint.Symbol != markets[0].Symbol {
+		t.Errorf("Expected waypoint '%s', got '%s'", markets[0].Symbol, waypoint.Symbol)
+	}
+}
+
+func hasTrait(waypoint schema.Waypoint, trait string) bool {
+	for _, t := range waypoint.Traits {
+		if t.Symbol == trait {
+			return true
+		}
+	}
+	return false
+}
+
+func testShipyardOperations(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	resp, err := client.RegisterAgent(ctx, "SHIPYARD_TEST", "COSMIC")
+	if err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	nav := resp.Ship.Nav
+	shipyard, err := client.GetShipyard(ctx, nav.SystemSymbol, nav.WaypointSymbol)
+	if err != nil {
+		t.Fatalf("Failed to get shipyard: %v", err)
+	}
+
+	if len(shipyard.Ships) == 0 {
+		t.Fatal("Expected shipyard to list ships for sale")
+	}
+
+	listing := shipyard.Ships[0]
+	purchase, err := client.PurchaseShip(ctx, listing.Type, nav.WaypointSymbol)
+	if err != nil {
+		t.Fatalf("Failed to purchase ship: %v", err)
+	}
+
+	expectedCredits := resp.Agent.Credits - int64(listing.PurchasePrice)
+	if purchase.Agent.Credits != expectedCredits {
+		t.Errorf("Expected %d credits after purchase, got %d", expectedCredits, purchase.Agent.Credits)
+	}
+
+	if purchase.Transaction.Price != listing.PurchasePrice {
+		t.Errorf("Expected transaction price %d, got %d", listing.PurchasePrice, purchase.Transaction.Price)
+	}
+
+	if purchase.Ship.Nav.WaypointSymbol != nav.WaypointSymbol {
+		t.Errorf("Expected new ship at %s, got %s", nav.WaypointSymbol, purchase.Ship.Nav.WaypointSymbol)
+	}
+
+	ships, err := client.GetFleet(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get fleet: %v", err)
+	}
+
+	if len(ships) != 2 {
+		t.Errorf("Expected 2 ships after purchase, got %d", len(ships))
+	}
+
+	if _, err := client.PurchaseShip(ctx, "SHIP_NONEXISTENT", nav.WaypointSymbol); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error purchasing unknown ship type, got: %v", err)
+	}
+}
+
+func testSiphonOperations(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	resp, err := client.RegisterAgent(ctx, "SIPHON_TEST", "COSMIC")
+	if err != nil {
+		t.Fatalf("Failed to register agent: %v", err)
+	}
+
+	shipSymbol := resp.Ship.Symbol
+
+	// Siphoning requires orbit at a gas giant
+	if _, err := client.SiphonResources(ctx, shipSymbol); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error siphoning while docked, got: %v", err)
+	}
+
+	ship, err := client.OrbitShip(ctx, shipSymbol)
+	if err != nil {
+		t.Fatalf("Failed to orbit ship: %v", err)
+	}
+	if ship.Nav.Status != "IN_ORBIT" {
+		t.Errorf("Expected ship IN_ORBIT, got %s", ship.Nav.Status)
+	}
+
+	gasGiants, err := client.GetWaypoints(ctx, resp.Ship.Nav.SystemSymbol, &schema.WaypointFilterOptions{
+		Type: "GAS_GIANT",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get gas giants: %v", err)
+	}
+	if len(gasGiants) == 0 {
+		t.Fatal("Expected a gas giant in the system")
+	}
+
+	nav, err := client.NavigateShip(ctx, shipSymbol, gasGiants[0].Symbol)
+	if err != nil {
+		t.Fatalf("Failed to navigate to gas giant: %v", err)
+	}
+	if nav.Fuel.Consumed == nil || nav.Fuel.Consumed.Amount <= 0 {
+		t.Errorf("Expected fuel consumption, got %+v", nav.Fuel)
+	}
+
+	siphon, err := client.SiphonResources(ctx, shipSymbol)
+	if err != nil {
+		t.Fatalf("Failed to siphon resources: %v", err)
+	}
+
+	if siphon.Siphon.Yield.Units <= 0 {
+		t.Errorf("Expected positive siphon yield, got %d", siphon.Siphon.Yield.Units)
+	}
+	if siphon.Cargo.Units != siphon.Siphon.Yield.Units {
+		t.Errorf("Expected cargo units %d, got %d", siphon.Siphon.Yield.Units, siphon.Cargo.Units)
+	}
+	if siphon.Cooldown.RemainingSeconds <= 0 {
+		t.Errorf("Expected active cooldown, got %+v", siphon.Cooldown)
+	}
+
+	cooldown, err := client.GetShipCooldown(ctx, shipSymbol)
+	if err != nil {
+		t.Fatalf("Failed to get ship cooldown: %v", err)
+	}
+	if cooldown == nil {
+		t.Fatal("Expected cooldown after siphoning")
+	}
+
+	_, err = client.SiphonResources(ctx, shipSymbol)
+	if !transport.IsCooldownError(err) {
+		t.Fatalf("Expected cooldown error, got: %v", err)
+	}
+
+	var cooldownErr *transport.CooldownError
+	if errors.As(err, &cooldownErr) && cooldownErr.Cooldown.RemainingSeconds <= 0 {
+		t.Errorf("Expected remaining cooldown on error, got %+v", cooldownErr.Cooldown)
+	}
+}
+
+func testFactionOperations(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	factions, err := client.ListAllFactions(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list all factions: %v", err)
+	}
+
+	// The mock serves more factions than fit on a default page
+	firstPage, err := client.GetFactions(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to get factions: %v", err)
+	}
+	if len(factions) <= len(firstPage) {
+		t.Errorf("Expected more factions than the first page of %d, got %d", len(firstPage), len(factions))
+	}
+
+	limit := 5
+	it := client.Factions(ctx, &schema.PaginationOptions{Limit: &limit})
+	count := 0
+	for it.Next() {
+		if it.Item().Symbol != factions[count].Symbol {
+			t.Errorf("Expected faction %s at %d, got %s", factions[count].Symbol, count, it.Item().Symbol)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Faction iteration failed: %v", err)
+	}
+	if count != len(factions) || it.Meta().Total != len(factions) {
+		t.Errorf("Expected %d factions, iterated %d (meta total %d)", len(factions), count, it.Meta().Total)
+	}
+
+	faction, err := client.GetFaction(ctx, factions[0].Symbol)
+	if err != nil {
+		t.Fatalf("Failed to get faction: %v", err)
+	}
+	if faction.Symbol != factions[0].Symbol {
+		t.Errorf("Expected faction %s, got %s", factions[0].Symbol, faction.Symbol)
+	}
+
+	if _, err := client.GetFaction(ctx, "NONEXISTENT"); !transport.IsAPIError(err) {
+		t.Errorf("Expected API error for unknown faction, got: %v", err)
+	}
+}
+
+func testServerStatus(t *testing.T, ctx context.Context, client *client.SpaceTradersClient) {
+	status, err := client.GetServerStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get server status: %v", err)
+	}
+
+	if status.Status == "" {
+		t.Error("Expected non-empty server status")
+	}
+
+	if !status.ServerResets.Next.After(time.Now()) {
+		t.Errorf("Expected next reset in the future, got %v", status.ServerResets.Next)
+	}
+
+	if len(status.Leaderboards.MostCredits) != status.Stats.Agents {
+		t.Errorf("Expected %d leaderboard entries, got %d", status.Stats.Agents, len(status.Leaderboards.MostCredits))
+	}
+
+	agents, err := client.ListAgents(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to list agents: %v", err)
+	}
+
+	if len(agents) == 0 {
+		t.Fatal("Expected registered agents to be listed")
+	}
+
+	agent, err := client.GetPublicAgent(ctx, agents[0].Symbol)
+	if err != nil {
+		t.Fatalf("Failed to get public agent: %v", err)
+	}
+
+	if agent.Symbol != agents[0].Symbol {
+		t.Errorf("Expected agent '%s', got '%s'", agents[0].Symbol, agent.Symbol)
+	}
+
+	if agent.AccountID != "" {
+		t.Error("Public agent should not expose account ID")
+	}
+}
+
 func testAuthentication(t *testing.T, ctx context.Context, clientInstance *client.SpaceTradersClient, mockServerURL string) {
 	// First, register an agent to get a valid token
 	authTestClient, err := client.New(&client.Config{
@@ -239,10 +639,14 @@ func testRateLimiting(t *testing.T, ctx context.Context, clientInstance *client.
 		t.Error("Expected some requests to succeed")
 	}
 
-	// The key test is that the rate limiter exists and is functional
+	// The key test is that the rate limiter exists and tracks the server's budget
 	state := clientInstance.GetRateLimiterState()
-	if state == nil {
-		t.Error("Expected rate limiter state")
+	if state.Capacity != 30 {
+		t.Errorf("Expected rate limiter capacity 30, got %d", state.Capacity)
+	}
+
+	if state.IsFull() {
+		t.Error("Expected rapid requests to drain the rate limiter")
 	}
 
 	t.Logf("Rate limiting test: %d successful, %d errors", successCount, errorCount)
@@ -0,0 +1,640 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newTestClient starts a server backed by handler and returns an authenticated client pointed at it
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.SpaceTradersClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.New(&client.Config{
+		BaseURL: server.URL,
+		Timeout: 5 * time.Second,
+		Token:   "test-token",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestExtractResources(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		var gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {
+				"cooldown": {"shipSymbol": "SHIP-1", "totalSeconds": 70, "remainingSeconds": 69},
+				"extraction": {"shipSymbol": "SHIP-1", "yield": {"symbol": "IRON_ORE", "units": 7}},
+				"cargo": {"capacity": 30, "units": 7, "inventory": [{"symbol": "IRON_ORE", "units": 7}]}
+			}}`))
+		})
+
+		result, err := c.ExtractResources(ctx, "SHIP-1", nil)
+		if err != nil {
+			t.Fatalf("ExtractResources failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/extract" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if result.Extraction.Yield.Units != 7 {
+			t.Errorf("Expected yield of 7 units, got %d", result.Extraction.Yield.Units)
+		}
+
+		if result.Cooldown.RemainingSeconds != 69 {
+			t.Errorf("Expected 69 seconds remaining, got %d", result.Cooldown.RemainingSeconds)
+		}
+
+		if result.Cargo.Units != 7 {
+			t.Errorf("Expected 7 cargo units, got %d", result.Cargo.Units)
+		}
+	})
+
+	t.Run("With Survey", func(t *testing.T) {
+		var gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {}}`))
+		})
+
+		survey := &schema.Survey{Signature: "X1-TEST-B2-1234", Symbol: "X1-TEST-B2"}
+		if _, err := c.ExtractResources(ctx, "SHIP-1", survey); err != nil {
+			t.Fatalf("ExtractResources failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/extract/survey" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": {"message": "Ship action is still on cooldown", "code": 4000,
+				"data": {"cooldown": {"shipSymbol": "SHIP-1", "totalSeconds": 70, "remainingSeconds": 42}}}}`))
+		})
+
+		_, err := c.ExtractResources(ctx, "SHIP-1", nil)
+		if !transport.IsCooldownError(err) {
+			t.Fatalf("Expected cooldown error, got: %v", err)
+		}
+
+		if !transport.IsAPIError(err) {
+			t.Error("Cooldown error should also be an API error")
+		}
+
+		cooldownErr := err.(*transport.CooldownError)
+		if cooldownErr.Cooldown.RemainingSeconds != 42 {
+			t.Errorf("Expected 42 seconds remaining, got %d", cooldownErr.Cooldown.RemainingSeconds)
+		}
+	})
+
+	t.Run("Survey Errors", func(t *testing.T) {
+		tests := []struct {
+			code  int
+			check func(error) bool
+		}{
+			{transport.ErrorCodeSurveyExhausted, transport.IsSurveyExhaustedError},
+			{transport.ErrorCodeSurveyExpired, transport.IsSurveyExpiredError},
+		}
+
+		for _, tt := range tests {
+			code := tt.code
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": {"message": "Survey is no longer usable", "code": ` + strconv.Itoa(code) + `}}`))
+			})
+
+			_, err := c.ExtractResources(ctx, "SHIP-1", &schema.Survey{Signature: "SIG"})
+			if !tt.check(err) {
+				t.Errorf("Code %d: unexpected error type %T", code, err)
+			}
+
+			if transport.IsCooldownError(err) {
+				t.Errorf("Code %d: should not be reported as a cooldown error", code)
+			}
+		}
+	})
+}
+
+func TestNavigation(t *testing.T) {
+	ctx := context.Background()
+	navResponse := `{"data": {
+		"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-B2", "status": "IN_TRANSIT", "flightMode": "DRIFT"},
+		"fuel": {"current": 62, "capacity": 100, "consumed": {"amount": 38, "timestamp": "2026-01-01T00:00:00Z"}},
+		"events": [{"symbol": "REACTOR_OVERLOAD", "component": "REACTOR", "name": "Reactor Overload", "description": "The reactor overloaded"}]
+	}}`
+
+	t.Run("Navigate", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(navResponse))
+		})
+
+		result, err := c.NavigateShip(ctx, "SHIP-1", "X1-TEST-B2")
+		if err != nil {
+			t.Fatalf("NavigateShip failed: %v", err)
+		}
+
+		if result.Nav.WaypointSymbol != "X1-TEST-B2" {
+			t.Errorf("Expected destination X1-TEST-B2, got %s", result.Nav.WaypointSymbol)
+		}
+
+		if result.Fuel.Consumed == nil || result.Fuel.Consumed.Amount != 38 {
+			t.Errorf("Expected 38 fuel consumed, got %+v", result.Fuel.Consumed)
+		}
+
+		if len(result.Events) != 1 || result.Events[0].Component != "REACTOR" {
+			t.Errorf("Expected one reactor event, got %+v", result.Events)
+		}
+	})
+
+	t.Run("Set Flight Mode", func(t *testing.T) {
+		var gotMethod string
+		var gotBody schema.SetFlightModeRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Write([]byte(navResponse))
+		})
+
+		result, err := c.SetFlightMode(ctx, "SHIP-1", schema.FlightModeDrift)
+		if err != nil {
+			t.Fatalf("SetFlightMode failed: %v", err)
+		}
+
+		if gotMethod != http.MethodPatch {
+			t.Errorf("Expected PATCH request, got %s", gotMethod)
+		}
+
+		if gotBody.FlightMode != schema.FlightModeDrift {
+			t.Errorf("Expected flight mode %s in request, got %s", schema.FlightModeDrift, gotBody.FlightMode)
+		}
+
+		if result.Nav.FlightMode != schema.FlightModeDrift {
+			t.Errorf("Expected flight mode %s, got %s", schema.FlightModeDrift, result.Nav.FlightMode)
+		}
+	})
+}
+
+func TestInterSystemTravel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Get Jump Gate", func(t *testing.T) {
+		var gotMethod, gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotPath = r.URL.Path
+			w.Write([]byte(`{"data": {"symbol": "X1-TEST-I9", "connections": ["X1-NEXT-I1", "X1-OTHER-I4"]}}`))
+		})
+
+		gate, err := c.GetJumpGate(ctx, "X1-TEST", "X1-TEST-I9")
+		if err != nil {
+			t.Fatalf("GetJumpGate failed: %v", err)
+		}
+
+		if gotMethod != http.MethodGet || gotPath != "/systems/X1-TEST/waypoints/X1-TEST-I9/jump-gate" {
+			t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+		}
+
+		if gate.Symbol != "X1-TEST-I9" || len(gate.Connections) != 2 || gate.Connections[1] != "X1-OTHER-I4" {
+			t.Errorf("Unexpected jump gate %+v", gate)
+		}
+	})
+
+	t.Run("Jump", func(t *testing.T) {
+		var gotPath string
+		var gotBody schema.JumpShipRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Write([]byte(`{"data": {
+				"nav": {"systemSymbol": "X1-NEXT", "waypointSymbol": "X1-NEXT-I1", "status": "IN_ORBIT", "flightMode": "CRUISE"},
+				"cooldown": {"shipSymbol": "SHIP-1", "totalSeconds": 60, "remainingSeconds": 60},
+				"transaction": {"waypointSymbol": "X1-TEST-I9", "shipSymbol": "SHIP-1", "tradeSymbol": "ANTIMATTER", "type": "PURCHASE", "units": 1, "totalPrice": 8000},
+				"agent": {"symbol": "AGENT", "credits": 92000}
+			}}`))
+		})
+
+		result, err := c.JumpShip(ctx, "SHIP-1", "X1-NEXT-I1")
+		if err != nil {
+			t.Fatalf("JumpShip failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/jump" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if gotBody.WaypointSymbol != "X1-NEXT-I1" {
+			t.Errorf("Expected waypoint X1-NEXT-I1 in request, got %s", gotBody.WaypointSymbol)
+		}
+
+		if result.Nav.SystemSymbol != "X1-NEXT" || result.Cooldown.RemainingSeconds != 60 {
+			t.Errorf("Unexpected jump result %+v", result)
+		}
+
+		if result.Transaction.TradeSymbol != "ANTIMATTER" || result.Agent.Credits != 92000 {
+			t.Errorf("Expected the antimatter purchase and updated agent, got %+v / %+v", result.Transaction, result.Agent)
+		}
+	})
+
+	t.Run("Warp", func(t *testing.T) {
+		var gotPath string
+		var gotBody schema.WarpShipRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Write([]byte(`{"data": {
+				"nav": {"systemSymbol": "X1-FAR", "waypointSymbol": "X1-FAR-A1", "status": "IN_TRANSIT", "flightMode": "CRUISE"},
+				"fuel": {"current": 120, "capacity": 800, "consumed": {"amount": 680, "timestamp": "2026-01-01T00:00:00Z"}},
+				"events": [{"symbol": "ENGINE_FAILURE", "component": "ENGINE", "name": "Engine Failure", "description": "The engine stalled"}]
+			}}`))
+		})
+
+		result, err := c.WarpShip(ctx, "SHIP-1", "X1-FAR-A1")
+		if err != nil {
+			t.Fatalf("WarpShip failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/warp" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if gotBody.WaypointSymbol != "X1-FAR-A1" {
+			t.Errorf("Expected waypoint X1-FAR-A1 in request, got %s", gotBody.WaypointSymbol)
+		}
+
+		if result.Nav.WaypointSymbol != "X1-FAR-A1" || result.Fuel.Current != 120 {
+			t.Errorf("Unexpected warp result %+v", result)
+		}
+
+		if len(result.Events) != 1 || result.Events[0].Component != "ENGINE" {
+			t.Errorf("Expected one engine event, got %+v", result.Events)
+		}
+	})
+}
+
+func TestCargoManagement(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Transfer", func(t *testing.T) {
+		var gotPath string
+		var gotBody schema.TransferCargoRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Write([]byte(`{"data": {
+				"cargo": {"capacity": 15, "units": 0, "inventory": []},
+				"targetCargo": {"capacity": 40, "units": 10, "inventory": [{"symbol": "IRON_ORE", "units": 10}]}
+			}}`))
+		})
+
+		result, err := c.TransferCargo(ctx, "DRONE-1", &schema.TransferCargoRequest{
+			TradeSymbol: "IRON_ORE",
+			Units:       10,
+			ShipSymbol:  "HAULER-1",
+		})
+		if err != nil {
+			t.Fatalf("TransferCargo failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/DRONE-1/transfer" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if gotBody.ShipSymbol != "HAULER-1" || gotBody.Units != 10 {
+			t.Errorf("Unexpected request body %+v", gotBody)
+		}
+
+		if result.Cargo.Units != 0 {
+			t.Errorf("Expected source cargo to be empty, got %d units", result.Cargo.Units)
+		}
+
+		if result.TargetCargo == nil || result.TargetCargo.Units != 10 {
+			t.Errorf("Expected target cargo with 10 units, got %+v", result.TargetCargo)
+		}
+	})
+
+	t.Run("Refine", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {
+				"cargo": {"capacity": 40, "units": 31, "inventory": [{"symbol": "IRON", "units": 1}, {"symbol": "IRON_ORE", "units": 30}]},
+				"cooldown": {"shipSymbol": "HAULER-1", "totalSeconds": 60, "remainingSeconds": 60},
+				"produced": [{"tradeSymbol": "IRON", "units": 1}],
+				"consumed": [{"tradeSymbol": "IRON_ORE", "units": 3}]
+			}}`))
+		})
+
+		result, err := c.RefineCargo(ctx, "HAULER-1", &schema.RefineCargoRequest{Produce: "IRON"})
+		if err != nil {
+			t.Fatalf("RefineCargo failed: %v", err)
+		}
+
+		if len(result.Produced) != 1 || result.Produced[0].TradeSymbol != "IRON" {
+			t.Errorf("Unexpected produced goods %+v", result.Produced)
+		}
+
+		if len(result.Consumed) != 1 || result.Consumed[0].Units != 3 {
+			t.Errorf("Unexpected consumed goods %+v", result.Consumed)
+		}
+
+		if result.Cooldown.TotalSeconds != 60 {
+			t.Errorf("Expected 60 second cooldown, got %d", result.Cooldown.TotalSeconds)
+		}
+	})
+}
+
+func TestShipOutfitting(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Install Mount", func(t *testing.T) {
+		var gotPath string
+		var gotBody schema.ShipMountRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {
+				"agent": {"symbol": "AGENT", "credits": 90000},
+				"mounts": [{"symbol": "MOUNT_MINING_LASER_II"}],
+				"cargo": {"capacity": 15, "units": 0, "inventory": []},
+				"transaction": {"waypointSymbol": "X1-TEST-A1", "shipSymbol": "AGENT-2", "tradeSymbol": "MOUNT_MINING_LASER_II", "totalPrice": 1000}
+			}}`))
+		})
+
+		result, err := c.InstallMount(ctx, "AGENT-2", "MOUNT_MINING_LASER_II")
+		if err != nil {
+			t.Fatalf("InstallMount failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/AGENT-2/mounts/install" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if gotBody.Symbol != "MOUNT_MINING_LASER_II" {
+			t.Errorf("Unexpected mount symbol %s", gotBody.Symbol)
+		}
+
+		if len(result.Mounts) != 1 || result.Transaction.TotalPrice != 1000 {
+			t.Errorf("Unexpected install result %+v", result)
+		}
+	})
+
+	t.Run("Worn Ship Repair Quote", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/my/ships/AGENT-2":
+				w.Write([]byte(`{"data": {"symbol": "AGENT-2",
+					"frame": {"symbol": "FRAME_DRONE", "condition": 0.42, "integrity": 0.87}}}`))
+			case "/my/ships/AGENT-2/repair":
+				w.Write([]byte(`{"data": {"transaction": {"shipSymbol": "AGENT-2", "totalPrice": 4200}}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+
+		ship, err := c.GetShip(ctx, "AGENT-2")
+		if err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if ship.Frame.Condition != 0.42 || ship.Frame.Integrity != 0.87 {
+			t.Errorf("Unexpected frame condition %v / integrity %v", ship.Frame.Condition, ship.Frame.Integrity)
+		}
+
+		quote, err := c.GetRepairQuote(ctx, "AGENT-2")
+		if err != nil {
+			t.Fatalf("GetRepairQuote failed: %v", err)
+		}
+
+		if quote.TotalPrice != 4200 {
+			t.Errorf("Expected repair price 4200, got %d", quote.TotalPrice)
+		}
+	})
+}
+
+func TestScanning(t *testing.T) {
+	ctx := context.Background()
+	cooldown := `"cooldown": {"shipSymbol": "SHIP-1", "totalSeconds": 70, "remainingSeconds": 70}`
+
+	t.Run("Create Chart", func(t *testing.T) {
+		var gotMethod, gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {
+				"chart": {"waypointSymbol": "X1-TEST-C3", "submittedBy": "AGENT"},
+				"waypoint": {"symbol": "X1-TEST-C3", "type": "ASTEROID", "systemSymbol": "X1-TEST"}
+			}}`))
+		})
+
+		result, err := c.CreateChart(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("CreateChart failed: %v", err)
+		}
+
+		if gotMethod != http.MethodPost || gotPath != "/my/ships/SHIP-1/chart" {
+			t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+		}
+
+		if result.Chart.SubmittedBy == nil || *result.Chart.SubmittedBy != "AGENT" {
+			t.Errorf("Expected chart submitted by AGENT, got %+v", result.Chart)
+		}
+
+		if result.Waypoint.Symbol != "X1-TEST-C3" {
+			t.Errorf("Expected charted waypoint X1-TEST-C3, got %s", result.Waypoint.Symbol)
+		}
+	})
+
+	t.Run("Scan Systems", func(t *testing.T) {
+		var gotMethod, gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {` + cooldown + `,
+				"systems": [{"symbol": "X1-NEAR", "sectorSymbol": "X1", "type": "RED_STAR", "x": 3, "y": -4, "distance": 5}]
+			}}`))
+		})
+
+		result, err := c.ScanSystems(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("ScanSystems failed: %v", err)
+		}
+
+		if gotMethod != http.MethodPost || gotPath != "/my/ships/SHIP-1/scan/systems" {
+			t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+		}
+
+		if len(result.Systems) != 1 || result.Systems[0].Distance != 5 {
+			t.Errorf("Unexpected scanned systems %+v", result.Systems)
+		}
+
+		if result.Cooldown.TotalSeconds != 70 {
+			t.Errorf("Expected 70 second cooldown, got %d", result.Cooldown.TotalSeconds)
+		}
+	})
+
+	t.Run("Scan Waypoints", func(t *testing.T) {
+		var gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {` + cooldown + `,
+				"waypoints": [{"symbol": "X1-TEST-D4", "type": "GAS_GIANT", "systemSymbol": "X1-TEST", "x": 10, "y": 12,
+					"orbitals": [], "traits": [{"symbol": "MARKETPLACE", "name": "Marketplace"}]}]
+			}}`))
+		})
+
+		result, err := c.ScanWaypoints(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("ScanWaypoints failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/scan/waypoints" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if len(result.Waypoints) != 1 || result.Waypoints[0].Type != "GAS_GIANT" {
+			t.Errorf("Unexpected scanned waypoints %+v", result.Waypoints)
+		}
+
+		if traits := result.Waypoints[0].Traits; len(traits) != 1 || traits[0].Symbol != "MARKETPLACE" {
+			t.Errorf("Expected the marketplace trait, got %+v", traits)
+		}
+
+		if result.Cooldown.RemainingSeconds != 70 {
+			t.Errorf("Expected 70 seconds remaining, got %d", result.Cooldown.RemainingSeconds)
+		}
+	})
+
+	t.Run("Scan Ships", func(t *testing.T) {
+		var gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {` + cooldown + `,
+				"ships": [{"symbol": "RIVAL-1", "registration": {"name": "RIVAL-1", "role": "HAULER"},
+					"nav": {"systemSymbol": "X1-TEST", "waypointSymbol": "X1-TEST-A1", "status": "DOCKED"},
+					"engine": {"symbol": "ENGINE_ION_DRIVE_I"}, "mounts": [{"symbol": "MOUNT_SENSOR_ARRAY_I"}]}]
+			}}`))
+		})
+
+		result, err := c.ScanShips(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("ScanShips failed: %v", err)
+		}
+
+		if gotPath != "/my/ships/SHIP-1/scan/ships" {
+			t.Errorf("Unexpected path %s", gotPath)
+		}
+
+		if len(result.Ships) != 1 {
+			t.Fatalf("Expected one scanned ship, got %d", len(result.Ships))
+		}
+
+		ship := result.Ships[0]
+		if ship.Registration.Role != "HAULER" || ship.Engine.Symbol != "ENGINE_ION_DRIVE_I" || len(ship.Mounts) != 1 {
+			t.Errorf("Unexpected scanned ship %+v", ship)
+		}
+
+		if result.Cooldown.ShipSymbol != "SHIP-1" {
+			t.Errorf("Expected the cooldown of SHIP-1, got %s", result.Cooldown.ShipSymbol)
+		}
+	})
+}
+
+func TestConstruction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Get Construction", func(t *testing.T) {
+		var gotMethod, gotPath string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotPath = r.URL.Path
+			w.Write([]byte(`{"data": {"symbol": "X1-TEST-I9", "isComplete": false, "materials": [
+				{"tradeSymbol": "FAB_MATS", "required": 4000, "fulfilled": 1200},
+				{"tradeSymbol": "ADVANCED_CIRCUITRY", "required": 1200, "fulfilled": 0}
+			]}}`))
+		})
+
+		construction, err := c.GetConstruction(ctx, "X1-TEST", "X1-TEST-I9")
+		if err != nil {
+			t.Fatalf("GetConstruction failed: %v", err)
+		}
+
+		if gotMethod != http.MethodGet || gotPath != "/systems/X1-TEST/waypoints/X1-TEST-I9/construction" {
+			t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+		}
+
+		if construction.IsComplete || len(construction.Materials) != 2 {
+			t.Fatalf("Unexpected construction %+v", construction)
+		}
+
+		if m := construction.Materials[0]; m.TradeSymbol != "FAB_MATS" || m.Required != 4000 || m.Fulfilled != 1200 {
+			t.Errorf("Unexpected material %+v", m)
+		}
+	})
+
+	t.Run("Supply", func(t *testing.T) {
+		var gotMethod, gotPath string
+		var gotBody schema.SupplyConstructionRequest
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod = r.Method
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {
+				"construction": {"symbol": "X1-TEST-I9", "isComplete": false, "materials": [{"tradeSymbol": "FAB_MATS", "required": 4000, "fulfilled": 1240}]},
+				"cargo": {"capacity": 40, "units": 0, "inventory": []}
+			}}`))
+		})
+
+		result, err := c.SupplyConstruction(ctx, "X1-TEST", "X1-TEST-I9", &schema.SupplyConstructionRequest{
+			ShipSymbol:  "HAULER-1",
+			TradeSymbol: "FAB_MATS",
+			Units:       40,
+		})
+		if err != nil {
+			t.Fatalf("SupplyConstruction failed: %v", err)
+		}
+
+		if gotMethod != http.MethodPost || gotPath != "/systems/X1-TEST/waypoints/X1-TEST-I9/construction/supply" {
+			t.Errorf("Unexpected request %s %s", gotMethod, gotPath)
+		}
+
+		if gotBody.ShipSymbol != "HAULER-1" || gotBody.TradeSymbol != "FAB_MATS" || gotBody.Units != 40 {
+			t.Errorf("Unexpected request body %+v", gotBody)
+		}
+
+		if len(result.Construction.Materials) != 1 || result.Construction.Materials[0].Fulfilled != 1240 {
+			t.Errorf("Unexpected construction %+v", result.Construction)
+		}
+
+		if result.Cargo.Capacity != 40 || result.Cargo.Units != 0 {
+			t.Errorf("Expected an empty 40 unit hold, got %+v", result.Cargo)
+		}
+	})
+}
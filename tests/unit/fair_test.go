@@ -0,0 +1,285 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// queueKeys queues one request per key while the bucket is paused, then
+// releases the bucket and returns the keys in the order they were granted
+func queueKeys(t *testing.T, weights map[string]int, keys ...string) ([]string, *ratelimit.FairQueue) {
+	t.Helper()
+
+	granted, queue := queueRequests(t, weights, keys, nil)
+
+	order := make([]string, len(granted))
+	for i, request := range granted {
+		order[i] = keys[request]
+	}
+	return order, queue
+}
+
+// queuedFor is how long queueRequests keeps its requests queued before
+// the first is granted; the rest are granted one second apart
+const queuedFor = time.Second
+
+// queueRequests queues one request per key with the matching priority, if
+// any, while the limiter is paused, then releases the limiter one request
+// at a time and returns the indexes of the requests in the order they were granted
+func queueRequests(t *testing.T, weights map[string]int, keys []string, priorities []ratelimit.Priority) ([]int, *ratelimit.FairQueue) {
+	t.Helper()
+
+	clock := newFakeClock()
+	limiter := ratelimit.NewCustomDualWindowLimiter(1, 0, time.Hour, clock)
+	limiter.PauseUntil(clock.Now().Add(time.Hour))
+	queue := ratelimit.NewFairQueue(limiter)
+	for key, weight := range weights {
+		queue.SetWeight(key, weight)
+	}
+
+	served := make(chan int, len(keys))
+	for i, key := range keys {
+		ctx := ratelimit.WithKey(context.Background(), key)
+		if priorities != nil {
+			ctx = ratelimit.WithPriority(ctx, priorities[i])
+		}
+
+		go func(i int) {
+			if err := queue.Wait(ctx); err != nil {
+				t.Errorf("Wait failed: %v", err)
+			}
+			served <- i
+		}(i)
+
+		// Queue in a known order
+		deadline := time.Now().Add(time.Second)
+		for queued(queue) < i+1 {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out queueing request %d", i+1)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// The queue is blocked on the pause until the limiter is reset
+	<-clock.waiters
+	clock.Advance(queuedFor)
+	limiter.Reset()
+
+	// Each refill grants exactly one request and schedules the next refill
+	order := []int{<-served}
+	for len(order) < len(keys) {
+		<-clock.waiters
+		clock.Advance(time.Second)
+		order = append(order, <-served)
+	}
+
+	return order, queue
+}
+
+// queued returns the number of requests waiting in the queue
+func queued(queue *ratelimit.FairQueue) int {
+	total := 0
+	for _, stats := range queue.Stats() {
+		total += stats.Waiting
+	}
+	return total
+}
+
+// grantedContext reports itself cancelled only once its request has been
+// granted, so FairQueue.Wait sees the grant and the cancellation at once
+type grantedContext struct {
+	context.Context
+	queue *ratelimit.FairQueue
+}
+
+// Done waits until the request has left the queue, then reports cancellation
+func (c *grantedContext) Done() <-chan struct{} {
+	for c.queue.KeyStats(ratelimit.KeyFromContext(c)).Waiting > 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Err reports the context as cancelled
+func (c *grantedContext) Err() error {
+	return context.Canceled
+}
+
+func TestFairQueue(t *testing.T) {
+	t.Run("Round Robin", func(t *testing.T) {
+		order, _ := queueKeys(t, nil, "SHIP-A", "SHIP-A", "SHIP-A", "SHIP-A", "SHIP-B", "SHIP-B")
+
+		want := []string{"SHIP-A", "SHIP-B", "SHIP-A", "SHIP-B", "SHIP-A", "SHIP-A"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Expected order %v, got %v", want, order)
+		}
+	})
+
+	t.Run("Weighted", func(t *testing.T) {
+		order, _ := queueKeys(t, map[string]int{"SHIP-A": 2},
+			"SHIP-A", "SHIP-A", "SHIP-A", "SHIP-A", "SHIP-B", "SHIP-B", "SHIP-C")
+
+		want := []string{"SHIP-A", "SHIP-A", "SHIP-B", "SHIP-C", "SHIP-A", "SHIP-A", "SHIP-B"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Expected order %v, got %v", want, order)
+		}
+	})
+
+	t.Run("Higher Priority Key First", func(t *testing.T) {
+		keys := []string{"SHIP-A", "SHIP-A", "SHIP-A", "SHIP-B", "SHIP-C"}
+		priorities := []ratelimit.Priority{
+			ratelimit.PriorityNormal, ratelimit.PriorityNormal, ratelimit.PriorityNormal,
+			ratelimit.PriorityNormal, ratelimit.PriorityHigh,
+		}
+		order, _ := queueRequests(t, nil, keys, priorities)
+
+		// The high priority request skips the round, which then resumes
+		want := []int{4, 0, 3, 1, 2}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Expected order %v, got %v", want, order)
+		}
+	})
+
+	t.Run("Priority Within Key", func(t *testing.T) {
+		keys := []string{"SHIP-A", "SHIP-A", "SHIP-A", "SHIP-B"}
+		priorities := []ratelimit.Priority{
+			ratelimit.PriorityLow, ratelimit.PriorityNormal, ratelimit.PriorityHigh, ratelimit.PriorityHigh,
+		}
+		order, _ := queueRequests(t, nil, keys, priorities)
+
+		// High priority requests of both keys take turns before the rest
+		want := []int{2, 3, 1, 0}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Expected order %v, got %v", want, order)
+		}
+	})
+
+	t.Run("Wait Statistics", func(t *testing.T) {
+		_, queue := queueKeys(t, nil, "SHIP-A", "SHIP-A", "SHIP-A", "SHIP-B")
+
+		stats := queue.KeyStats("SHIP-A")
+		if stats.Requests != 3 || stats.Waiting != 0 {
+			t.Errorf("Expected 3 granted and none waiting, got %+v", stats)
+		}
+
+		// SHIP-A is granted the first, third and fourth token
+		if stats.MaxWait != queuedFor+3*time.Second || stats.TotalWait != 3*queuedFor+5*time.Second {
+			t.Errorf("Expected waits measured on the limiter's clock, got %+v", stats)
+		}
+
+		if b := queue.KeyStats("SHIP-B"); b.Requests != 1 || b.Weight != 1 {
+			t.Errorf("Expected one request with default weight, got %+v", b)
+		}
+	})
+
+	t.Run("Cancelled Request", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(1, time.Hour)
+		bucket.Allow()
+		queue := ratelimit.NewFairQueue(bucket)
+
+		ctx, cancel := context.WithTimeout(ratelimit.WithKey(context.Background(), "SHIP-A"), 20*time.Millisecond)
+		defer cancel()
+
+		if err := queue.Wait(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+
+		if stats := queue.KeyStats("SHIP-A"); stats.Waiting != 0 || stats.Requests != 0 {
+			t.Errorf("Expected the cancelled request to leave the queue, got %+v", stats)
+		}
+	})
+
+	t.Run("Cancelled As Granted", func(t *testing.T) {
+		// Wait picks either outcome at random, so try both several times
+		for i := 0; i < 20; i++ {
+			queue := ratelimit.NewFairQueue(ratelimit.NewCustomTokenBucket(1, time.Hour))
+			ctx := &grantedContext{Context: ratelimit.WithKey(context.Background(), "SHIP-A"), queue: queue}
+
+			if err := queue.Wait(ctx); err == nil {
+				if stats := queue.KeyStats("SHIP-A"); stats.Requests != 1 {
+					t.Fatalf("Expected the granted request to be counted, got %+v", stats)
+				}
+				continue
+			}
+
+			// The only token must be passed on rather than lost
+			next, cancel := context.WithTimeout(ratelimit.WithKey(context.Background(), "SHIP-B"), time.Second)
+			err := queue.Wait(next)
+			cancel()
+			if err != nil {
+				t.Fatalf("Expected the cancelled request's token to be passed on, got %v", err)
+			}
+
+			if stats := queue.KeyStats("SHIP-A"); stats.Requests != 0 {
+				t.Errorf("Expected the cancelled request not to be counted, got %+v", stats)
+			}
+		}
+	})
+
+	t.Run("Stale Token Not Passed On", func(t *testing.T) {
+		// Wait picks either outcome at random, so retry until the token is left over
+		for i := 0; i < 100; i++ {
+			clock := newFakeClock()
+			limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+			queue := ratelimit.NewFairQueue(limiter)
+			ctx := &grantedContext{Context: ratelimit.WithKey(context.Background(), "SHIP-A"), queue: queue}
+			if err := queue.Wait(ctx); err == nil {
+				continue
+			}
+
+			// By now the limiter has refilled, so the left over token must not be spent too
+			clock.Advance(time.Second)
+			if err := queue.Wait(ratelimit.WithKey(context.Background(), "SHIP-B")); err != nil {
+				t.Fatalf("Wait failed: %v", err)
+			}
+
+			if tokens := limiter.GetState().Tokens; tokens != 1 {
+				t.Errorf("Expected the request to take a fresh token, got %d tokens left", tokens)
+			}
+			return
+		}
+		t.Fatal("Expected a cancelled request to leave its token over")
+	})
+}
+
+func TestClientFairShare(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(&client.Config{
+		BaseURL:   server.URL,
+		Timeout:   5 * time.Second,
+		Token:     "test-token",
+		FairShare: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer c.Close()
+	c.SetShipWeight("SHIP-1", 3)
+
+	ctx := transport.WithRateLimitKey(context.Background(), "SHIP-1")
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+	}
+
+	stats := c.GetShipWaitStats()["SHIP-1"]
+	if stats.Requests != 2 || stats.Weight != 3 {
+		t.Errorf("Expected 2 requests with weight 3 for SHIP-1, got %+v", stats)
+	}
+}
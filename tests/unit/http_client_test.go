@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// idleCounter is a transport that counts calls to CloseIdleConnections
+type idleCounter struct {
+	http.RoundTripper
+	closed int32
+}
+
+func (t *idleCounter) CloseIdleConnections() {
+	atomic.AddInt32(&t.closed, 1)
+}
+
+func TestCustomHTTPClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Custom Transport", func(t *testing.T) {
+		var gotURL string
+		c, err := client.New(&client.Config{
+			BaseURL: "https://api.example.test/v2",
+			Token:   "test-token",
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"data": {"symbol": "SHIP-1"}}`)),
+					Request:    req,
+				}, nil
+			}),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		ship, err := c.GetShip(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if ship.Symbol != "SHIP-1" || gotURL != "https://api.example.test/v2/my/ships/SHIP-1" {
+			t.Errorf("Expected request through custom transport, got ship %q from %s", ship.Symbol, gotURL)
+		}
+	})
+
+	t.Run("Custom HTTP Client", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+		}))
+		defer server.Close()
+
+		// The test server's certificate is only trusted by its own client
+		c, err := client.New(&client.Config{
+			BaseURL:    server.URL,
+			Token:      "test-token",
+			HTTPClient: server.Client(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip over TLS failed: %v", err)
+		}
+	})
+
+	t.Run("Caller's Connections Left Open", func(t *testing.T) {
+		shared := &idleCounter{RoundTripper: http.DefaultTransport}
+		for _, config := range []*client.Config{
+			{HTTPClient: &http.Client{Transport: shared}},
+			{Transport: shared},
+		} {
+			config.Token = "test-token"
+			c, err := client.New(config)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			c.Close()
+		}
+
+		if closed := atomic.LoadInt32(&shared.closed); closed != 0 {
+			t.Errorf("Expected the caller's idle connections to be left open, closed %d times", closed)
+		}
+	})
+}
+
+func TestConnectionReuse(t *testing.T) {
+	ctx := context.Background()
+
+	// countConnections serves ships and counts the connections opened by the client
+	countConnections := func(t *testing.T, config *client.Config) int32 {
+		t.Helper()
+
+		var connections int32
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+		}))
+		server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&connections, 1)
+			}
+		}
+		server.Start()
+		defer server.Close()
+
+		config.BaseURL = server.URL
+		config.Timeout = 5 * time.Second
+		config.Token = "test-token"
+		c, err := client.New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		for i := 0; i < 5; i++ {
+			if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+				t.Fatalf("GetShip failed: %v", err)
+			}
+		}
+
+		return atomic.LoadInt32(&connections)
+	}
+
+	if n := countConnections(t, &client.Config{MaxIdleConnsPerHost: 4, KeepAlive: 15 * time.Second}); n != 1 {
+		t.Errorf("Expected sequential requests to share one connection, got %d", n)
+	}
+
+	if n := countConnections(t, &client.Config{DisableKeepAlives: true}); n != 5 {
+		t.Errorf("Expected a new connection per request with keep-alives disabled, got %d", n)
+	}
+}
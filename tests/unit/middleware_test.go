@@ -0,0 +1,127 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMiddlewareTestClient returns a client with the given middleware, retrying quickly
+func newMiddlewareTestClient(t *testing.T, handler http.HandlerFunc, middleware ...transport.Middleware) *client.SpaceTradersClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := client.New(&client.Config{
+		BaseURL:     server.URL,
+		Timeout:     5 * time.Second,
+		Token:       "test-token",
+		RetryPolicy: &transport.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Middleware:  middleware,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+func TestMiddleware(t *testing.T) {
+	ctx := context.Background()
+	shipHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+	}
+
+	t.Run("Runs In Order", func(t *testing.T) {
+		var calls []string
+		trace := func(name string) transport.Middleware {
+			return transport.Hooks(
+				func(ctx context.Context, req *transport.Request) { calls = append(calls, name+" before") },
+				func(ctx context.Context, req *transport.Request, resp *transport.Response, err error) {
+					calls = append(calls, name+" after")
+				},
+			)
+		}
+
+		c := newMiddlewareTestClient(t, shipHandler, trace("outer"), trace("inner"))
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		want := []string{"outer before", "inner before", "inner after", "outer after"}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("Expected calls %v, got %v", want, calls)
+		}
+	})
+
+	t.Run("Hooks See Request And Response", func(t *testing.T) {
+		var gotHeader string
+		var gotStatus int
+		var gotPath string
+
+		c := newMiddlewareTestClient(t,
+			func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get("X-Trace-Id")
+				shipHandler(w, r)
+			},
+			transport.Hooks(
+				func(ctx context.Context, req *transport.Request) {
+					if req.Headers == nil {
+						req.Headers = map[string]string{}
+					}
+					req.Headers["X-Trace-Id"] = "trace-1"
+				},
+				func(ctx context.Context, req *transport.Request, resp *transport.Response, err error) {
+					gotPath = req.Path
+					gotStatus = resp.StatusCode
+				},
+			),
+		)
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if gotHeader != "trace-1" {
+			t.Errorf("Expected mutated request header, got %q", gotHeader)
+		}
+
+		if gotPath != "/my/ships/SHIP-1" || gotStatus != http.StatusOK {
+			t.Errorf("Expected after hook to see /my/ships/SHIP-1 with status 200, got %s with %d", gotPath, gotStatus)
+		}
+	})
+
+	t.Run("Fault Injection Triggers Retry", func(t *testing.T) {
+		var requests, attempts int32
+		injectFault := func(next transport.Handler) transport.Handler {
+			return func(ctx context.Context, req *transport.Request) (*transport.Response, error) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					return &transport.Response{StatusCode: http.StatusServiceUnavailable, Headers: http.Header{}},
+						&transport.APIError{StatusCode: http.StatusServiceUnavailable, Message: "injected"}
+				}
+				return next(ctx, req)
+			}
+		}
+
+		c := newMiddlewareTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			shipHandler(w, r)
+		}, injectFault)
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if attempts != 2 || requests != 1 {
+			t.Errorf("Expected 2 attempts reaching the server once, got %d attempts and %d requests", attempts, requests)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// fleetHandler serves a fleet of total ships split into pages, counting requests
+func fleetHandler(total int, requests *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		ships := []schema.Ship{}
+		for i := (page-1)*limit + 1; i <= page*limit && i <= total; i++ {
+			ships = append(ships, schema.Ship{Symbol: fmt.Sprintf("SHIP-%d", i)})
+		}
+
+		json.NewEncoder(w).Encode(schema.APIResponse[[]schema.Ship]{
+			Data: ships,
+			Meta: &schema.Meta{Total: total, Page: page, Limit: limit},
+		})
+	}
+}
+
+func TestShipsIterator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Follows All Pages", func(t *testing.T) {
+		var requests int32
+		c := newTestClient(t, fleetHandler(45, &requests))
+
+		ships, err := c.ListAllShips(ctx)
+		if err != nil {
+			t.Fatalf("ListAllShips failed: %v", err)
+		}
+
+		if len(ships) != 45 {
+			t.Fatalf("Expected 45 ships, got %d", len(ships))
+		}
+
+		for i, ship := range ships {
+			if want := fmt.Sprintf("SHIP-%d", i+1); ship.Symbol != want {
+				t.Errorf("Expected ship %d to be %s, got %s", i, want, ship.Symbol)
+			}
+		}
+
+		if requests != 3 {
+			t.Errorf("Expected 3 page requests, got %d", requests)
+		}
+	})
+
+	t.Run("Custom Page Size", func(t *testing.T) {
+		var requests int32
+		c := newTestClient(t, fleetHandler(10, &requests))
+
+		limit := 4
+		it := c.Ships(ctx, &schema.PaginationOptions{Limit: &limit})
+
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iteration failed: %v", err)
+		}
+
+		if count != 10 {
+			t.Errorf("Expected 10 ships, got %d", count)
+		}
+
+		if requests != 3 {
+			t.Errorf("Expected 3 page requests, got %d", requests)
+		}
+
+		if meta := it.Meta(); meta == nil || meta.Page != 3 || meta.Total != 10 {
+			t.Errorf("Unexpected final page meta: %+v", meta)
+		}
+	})
+
+	t.Run("Early Stop", func(t *testing.T) {
+		var requests int32
+		c := newTestClient(t, fleetHandler(45, &requests))
+
+		it := c.Ships(ctx, nil)
+		for i := 0; i < 5 && it.Next(); i++ {
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected only the first page to be requested, got %d requests", requests)
+		}
+	})
+
+	t.Run("Empty Fleet", func(t *testing.T) {
+		var requests int32
+		c := newTestClient(t, fleetHandler(0, &requests))
+
+		ships, err := c.ListAllShips(ctx)
+		if err != nil {
+			t.Fatalf("ListAllShips failed: %v", err)
+		}
+
+		if len(ships) != 0 {
+			t.Errorf("Expected no ships, got %d", len(ships))
+		}
+	})
+
+	t.Run("Page Error", func(t *testing.T) {
+		pages := fleetHandler(45, new(int32))
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "2" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error": {"message": "Internal error", "code": 500}}`))
+				return
+			}
+			pages(w, r)
+		})
+
+		it := c.Ships(ctx, nil)
+		count := 0
+		for it.Next() {
+			count++
+		}
+
+		if count != 20 {
+			t.Errorf("Expected the first page of 20 ships before the error, got %d", count)
+		}
+
+		if !transport.IsAPIError(it.Err()) {
+			t.Errorf("Expected API error, got: %v", it.Err())
+		}
+
+		if it.Next() {
+			t.Error("Expected iterator to stay stopped after an error")
+		}
+	})
+
+	t.Run("Missing Meta", func(t *testing.T) {
+		var requests int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Write([]byte(`{"data": [{"symbol": "SHIP-1"}, {"symbol": "SHIP-2"}]}`))
+		})
+
+		ships, err := c.ListAllShips(ctx)
+		if err != nil {
+			t.Fatalf("ListAllShips failed: %v", err)
+		}
+
+		if len(ships) != 2 || requests != 1 {
+			t.Errorf("Expected 2 ships from a single request, got %d ships from %d requests", len(ships), requests)
+		}
+	})
+}
+
+func TestWaypointsIteratorKeepsFilter(t *testing.T) {
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		json.NewEncoder(w).Encode(schema.APIResponse[[]schema.Waypoint]{
+			Data: []schema.Waypoint{{Symbol: fmt.Sprintf("X1-WP-%d", page)}},
+			Meta: &schema.Meta{Total: 2, Page: page, Limit: 1},
+		})
+	})
+
+	limit := 1
+	waypoints, err := c.ListAllWaypoints(context.Background(), "X1-WP", &schema.WaypointFilterOptions{
+		PaginationOptions: schema.PaginationOptions{Limit: &limit},
+		Traits:            []string{"MARKETPLACE"},
+	})
+	if err != nil {
+		t.Fatalf("ListAllWaypoints failed: %v", err)
+	}
+
+	if len(waypoints) != 2 {
+		t.Fatalf("Expected 2 waypoints, got %d", len(waypoints))
+	}
+
+	want := []string{"limit=1&page=1&traits=MARKETPLACE", "limit=1&page=2&traits=MARKETPLACE"}
+	for i, query := range queries {
+		if query != want[i] {
+			t.Errorf("Request %d: expected query %q, got %q", i, want[i], query)
+		}
+	}
+}
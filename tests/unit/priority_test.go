@@ -0,0 +1,260 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n requests are queued in the limiter
+func waitForWaiters(t *testing.T, state func() ratelimit.BucketState, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for state().Waiting < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %d queued requests, have %d", n, state().Waiting)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// queueWaiters starts a Wait for each priority in turn, reporting names in the order they are served
+func queueWaiters(t *testing.T, limiter *ratelimit.DualWindowLimiter, names []string, priorities []ratelimit.Priority) <-chan string {
+	t.Helper()
+
+	served := make(chan string, len(names))
+	for i, name := range names {
+		name, ctx := name, ratelimit.WithPriority(context.Background(), priorities[i])
+		go func() {
+			if err := limiter.Wait(ctx); err == nil {
+				served <- name
+			}
+		}()
+		waitForWaiters(t, limiter.GetState, i+1)
+	}
+
+	return served
+}
+
+// servedContext reports itself cancelled only once a queued request has
+// been served, so Wait sees the grant and the cancellation at once
+type servedContext struct {
+	context.Context
+	state   func() ratelimit.BucketState
+	waiting int // Requests queued before one is served
+}
+
+// Done waits until a request has left the queue, then reports cancellation
+func (c *servedContext) Done() <-chan struct{} {
+	for c.state().Waiting >= c.waiting {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+// Err reports the context as cancelled
+func (c *servedContext) Err() error {
+	return context.Canceled
+}
+
+func TestPriorityScheduling(t *testing.T) {
+	t.Run("Higher Priority First", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		served := queueWaiters(t,
+			limiter,
+			[]string{"low", "normal-1", "high-1", "normal-2", "high-2"},
+			[]ratelimit.Priority{ratelimit.PriorityLow, ratelimit.PriorityNormal, ratelimit.PriorityHigh, ratelimit.PriorityNormal, ratelimit.PriorityHigh},
+		)
+
+		// Each refill serves exactly one waiter and schedules the next refill
+		var order []string
+		for i := 0; i < 5; i++ {
+			<-clock.waiters
+			clock.Advance(500 * time.Millisecond)
+			order = append(order, <-served)
+		}
+
+		want := []string{"high-1", "high-2", "normal-1", "normal-2", "low"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("Expected order %v, got %v", want, order)
+		}
+	})
+
+	t.Run("Aging Prevents Starvation", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		limiter.SetPriorityAging(100 * time.Millisecond)
+		allowN(limiter, 2)
+
+		served := queueWaiters(t, limiter, []string{"low"}, []ratelimit.Priority{ratelimit.PriorityLow})
+		<-clock.waiters
+		clock.Advance(400 * time.Millisecond)
+
+		// A high priority request arriving later is outranked by the long wait
+		high := make(chan error, 1)
+		go func() {
+			high <- limiter.Wait(ratelimit.WithPriority(context.Background(), ratelimit.PriorityHigh))
+		}()
+		waitForWaiters(t, limiter.GetState, 2)
+
+		clock.Advance(100 * time.Millisecond)
+		select {
+		case <-served:
+		case <-high:
+			t.Fatal("Expected the aged low priority request to be served first")
+		}
+		if waiting := limiter.GetState().Waiting; waiting != 1 {
+			t.Fatalf("Expected the high priority request to still be queued, got %d waiting", waiting)
+		}
+
+		<-clock.waiters
+		clock.Advance(500 * time.Millisecond)
+		if err := <-high; err != nil {
+			t.Errorf("Wait failed: %v", err)
+		}
+	})
+
+	t.Run("Allow Does Not Jump Queue", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		served := queueWaiters(t, limiter, []string{"waiter"}, []ratelimit.Priority{ratelimit.PriorityNormal})
+		<-clock.waiters
+
+		limiter.PauseUntil(clock.Now().Add(time.Second))
+		clock.Advance(500 * time.Millisecond)
+		if limiter.TryAllow() {
+			t.Error("Expected TryAllow to fail while a request is queued")
+		}
+
+		<-clock.waiters
+		clock.Advance(500 * time.Millisecond)
+		<-served
+	})
+
+	t.Run("Cancelled Waiter Leaves Queue", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- limiter.Wait(ratelimit.WithPriority(ctx, ratelimit.PriorityHigh))
+		}()
+		waitForWaiters(t, limiter.GetState, 1)
+
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+
+		if waiting := limiter.GetState().Waiting; waiting != 0 {
+			t.Errorf("Expected an empty queue, got %d waiting", waiting)
+		}
+	})
+
+	t.Run("Cancelled As Granted", func(t *testing.T) {
+		// Wait picks either outcome at random, so try both several times
+		for i := 0; i < 20; i++ {
+			clock := newFakeClock()
+			limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+			allowN(limiter, 2)
+
+			next, cancel := context.WithCancel(context.Background())
+			served := make(chan error, 1)
+			go func() {
+				served <- limiter.Wait(next)
+			}()
+			waitForWaiters(t, limiter.GetState, 1)
+
+			ctx := &servedContext{Context: ratelimit.WithPriority(context.Background(), ratelimit.PriorityHigh), state: limiter.GetState, waiting: 2}
+			granted := make(chan error, 1)
+			go func() {
+				granted <- limiter.Wait(ctx)
+			}()
+			waitForWaiters(t, limiter.GetState, 2)
+
+			// One refill serves the high priority request only
+			<-clock.waiters
+			clock.Advance(500 * time.Millisecond)
+			if err := <-granted; err == nil {
+				cancel()
+				<-served
+				continue
+			}
+
+			// Its allowance must be passed on rather than lost
+			select {
+			case err := <-served:
+				if err != nil {
+					t.Fatalf("Expected the cancelled request's allowance to be passed on, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected the cancelled request's allowance to be passed on")
+			}
+			cancel()
+		}
+	})
+}
+
+func TestTransportPriority(t *testing.T) {
+	var mutex sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		paths = append(paths, r.URL.Path)
+		mutex.Unlock()
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	bucket := ratelimit.NewCustomTokenBucket(1, 200*time.Millisecond)
+	httpClient := transport.NewHTTPClient(&transport.Config{
+		BaseURL:     server.URL,
+		Timeout:     5 * time.Second,
+		RateLimiter: bucket,
+	})
+
+	get := func(ctx context.Context, path string) {
+		if _, err := httpClient.Do(ctx, &transport.Request{Method: http.MethodGet, Path: path}); err != nil {
+			t.Errorf("Request to %s failed: %v", path, err)
+		}
+	}
+
+	// Drain the bucket, then queue a scan ahead of a sale
+	get(context.Background(), "/first")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		get(transport.WithPriority(context.Background(), transport.PriorityLow), "/scan")
+	}()
+	waitForWaiters(t, bucket.GetState, 1)
+	go func() {
+		defer wg.Done()
+		get(transport.WithPriority(context.Background(), transport.PriorityHigh), "/sell")
+	}()
+	waitForWaiters(t, bucket.GetState, 2)
+	wg.Wait()
+
+	want := []string{"/first", "/sell", "/scan"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Expected requests in order %v, got %v", want, paths)
+	}
+}
@@ -3,6 +3,10 @@ package unit
 import (
 	"context"
 	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -67,6 +71,23 @@ func TestTokenBucket(t *testing.T) {
 		}
 	})
 
+	t.Run("Refill Carries Remainder", func(t *testing.T) {
+		refillRate := 50 * time.Millisecond
+		bucket := ratelimit.NewCustomTokenBucket(10, refillRate)
+		for bucket.Allow() {
+		}
+		before := bucket.GetState()
+
+		time.Sleep(3 * refillRate / 2)
+
+		// The partial refill since the last token must not be discarded
+		after := bucket.GetState()
+		advanced := after.LastRefill.Sub(before.LastRefill)
+		if advanced <= 0 || advanced%refillRate != 0 {
+			t.Errorf("Expected the refill time to advance by whole refills, advanced %v", advanced)
+		}
+	})
+
 	t.Run("Wait Method", func(t *testing.T) {
 		bucket := ratelimit.NewCustomTokenBucket(1, 200*time.Millisecond)
 
@@ -197,6 +218,102 @@ func TestTokenBucket(t *testing.T) {
 	})
 }
 
+func TestTokenBucketServerSync(t *testing.T) {
+	t.Run("Set Remaining Only Lowers", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+
+		bucket.SetRemaining(4)
+		if tokens := bucket.GetState().Tokens; tokens != 4 {
+			t.Errorf("Expected 4 tokens after sync, got %d", tokens)
+		}
+
+		bucket.SetRemaining(8)
+		if tokens := bucket.GetState().Tokens; tokens != 4 {
+			t.Errorf("Expected a higher remaining count to be ignored, got %d tokens", tokens)
+		}
+	})
+
+	t.Run("Pause Blocks Tokens", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+		bucket.PauseUntil(time.Now().Add(50 * time.Millisecond))
+
+		if bucket.TryAllow() {
+			t.Error("Paused bucket should not allow requests")
+		}
+
+		state := bucket.GetState()
+		if state.PausedUntil.IsZero() || state.AvailableIn() <= 0 {
+			t.Errorf("Expected state to report the pause, got %+v", state)
+		}
+
+		start := time.Now()
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+			t.Errorf("Expected Wait to last until the pause ends, took %v", elapsed)
+		}
+	})
+
+	t.Run("Reset Clears Pause", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+		bucket.PauseUntil(time.Now().Add(time.Hour))
+		bucket.Reset()
+
+		if !bucket.TryAllow() {
+			t.Error("Reset bucket should allow requests")
+		}
+	})
+}
+
+func TestClientRateLimiterSync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Remaining Header", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-ratelimit-remaining", "3")
+			w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+		})
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if state := c.GetRateLimiterState(); state.Tokens != 3 {
+			t.Errorf("Expected the limiter to adopt the server's remaining count, got %d tokens", state.Tokens)
+		}
+	})
+
+	t.Run("Rate Limited Response Pauses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-ratelimit-remaining", "0")
+			w.Header().Set("x-ratelimit-reset", time.Now().Add(time.Minute).Format(time.RFC3339Nano))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		c, err := client.New(&client.Config{
+			BaseURL:     server.URL,
+			Token:       "test-token",
+			RetryPolicy: &transport.RetryPolicy{MaxAttempts: 1},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); !transport.IsRateLimitError(err) {
+			t.Fatalf("Expected rate limit error, got: %v", err)
+		}
+
+		state := c.GetRateLimiterState()
+		if state.Tokens != 0 || state.AvailableIn() < 50*time.Second {
+			t.Errorf("Expected the limiter to pause until the reset, got %+v", state)
+		}
+	})
+}
+
 func BenchmarkTokenBucket(b *testing.B) {
 	bucket := ratelimit.NewTokenBucket()
 
@@ -0,0 +1,231 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReservation(t *testing.T) {
+	t.Run("Within Capacity", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+
+		r := bucket.Reserve(4)
+		if !r.OK() || r.Delay() != 0 {
+			t.Fatalf("Expected an immediately usable reservation, got delay %v", r.Delay())
+		}
+
+		if tokens := bucket.GetState().Tokens; tokens != 6 {
+			t.Errorf("Expected 6 tokens left, got %d", tokens)
+		}
+	})
+
+	t.Run("Borrows Against Refills", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(5, 100*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			bucket.Allow()
+		}
+
+		r := bucket.Reserve(4)
+		if delay := r.Delay(); delay <= 100*time.Millisecond || delay > 200*time.Millisecond {
+			t.Errorf("Expected two refills of delay, got %v", delay)
+		}
+
+		if bucket.TryAllow() {
+			t.Error("Expected the bucket to be in debt")
+		}
+
+		if available := bucket.GetState().AvailableIn(); available <= r.Delay() {
+			t.Errorf("Expected the next token after the reservation, got %v vs %v", available, r.Delay())
+		}
+	})
+
+	t.Run("Cancel Returns Unused Requests", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+
+		r := bucket.Reserve(4)
+		if used, err := r.Use(context.Background()); !used || err != nil {
+			t.Fatalf("Expected to use a reserved request, got %v, %v", used, err)
+		}
+
+		r.Cancel()
+		r.Cancel()
+		if tokens := bucket.GetState().Tokens; tokens != 9 {
+			t.Errorf("Expected the 3 unused requests back, got %d tokens", tokens)
+		}
+
+		if r.Remaining() != 0 {
+			t.Errorf("Expected no requests left after cancelling, got %d", r.Remaining())
+		}
+	})
+
+	t.Run("Cancelled While Using", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(5, time.Hour)
+		for i := 0; i < 3; i++ {
+			bucket.Allow()
+		}
+
+		r := bucket.Reserve(4)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			_, err := r.Use(ctx)
+			done <- err
+		}()
+
+		deadline := time.Now().Add(time.Second)
+		for r.Remaining() == 4 {
+			if time.Now().After(deadline) {
+				t.Fatal("Timed out waiting for Use to claim a request")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		// Cancelling returns the other 3, and the abandoned request follows
+		r.Cancel()
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+
+		if tokens := bucket.GetState().Tokens; tokens != 2 {
+			t.Errorf("Expected all 4 reserved requests back, got %d tokens", tokens)
+		}
+	})
+
+	t.Run("Exceeds Capacity", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(10, time.Hour)
+
+		r := bucket.Reserve(11)
+		if r.OK() || r.Delay() != ratelimit.InfDuration {
+			t.Errorf("Expected a rejected reservation, got OK %v with delay %v", r.OK(), r.Delay())
+		}
+
+		if err := r.Wait(context.Background()); !errors.Is(err, ratelimit.ErrExceedsCapacity) {
+			t.Errorf("Expected ErrExceedsCapacity, got %v", err)
+		}
+
+		if tokens := bucket.GetState().Tokens; tokens != 10 {
+			t.Errorf("Expected a rejected reservation to take nothing, got %d tokens", tokens)
+		}
+	})
+
+	t.Run("Fewer Than One Request", func(t *testing.T) {
+		bucket := ratelimit.NewCustomTokenBucket(30, time.Hour)
+		window := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, newFakeClock())
+		limiters := []ratelimit.Reserver{bucket, window, newFileLimiters(t, 1, 30, time.Hour)[0]}
+
+		for _, limiter := range limiters {
+			for _, n := range []int{0, -20} {
+				r := limiter.Reserve(n)
+				if r.OK() {
+					t.Errorf("%T: expected reserving %d requests to be rejected", limiter, n)
+				}
+
+				if err := r.Wait(context.Background()); !errors.Is(err, ratelimit.ErrInvalidReservation) {
+					t.Errorf("%T: expected ErrInvalidReservation, got %v", limiter, err)
+				}
+			}
+
+			if state := limiter.(ratelimit.Limiter).GetState(); state.Tokens != state.Capacity {
+				t.Errorf("%T: expected rejected reservations to leave the budget alone, got %+v", limiter, state)
+			}
+		}
+	})
+
+	t.Run("Dual Window Exact Ready Time", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, clock)
+		allowN(limiter, 32)
+
+		r := limiter.Reserve(3)
+		if want := clock.Now().Add(1500 * time.Millisecond); !r.ReadyAt().Equal(want) {
+			t.Errorf("Expected the reservation to be ready at %v, got %v", want, r.ReadyAt())
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := r.Use(context.Background())
+			done <- err
+		}()
+
+		<-clock.waiters
+		clock.Advance(1500 * time.Millisecond)
+		if err := <-done; err != nil {
+			t.Fatalf("Use failed: %v", err)
+		}
+
+		// The remaining two reserved requests are returned
+		r.Cancel()
+		clock.Advance(time.Second)
+		if allowed := allowN(limiter, 5); allowed != 3 {
+			t.Errorf("Expected 2 refunded plus 1 burst request, got %d", allowed)
+		}
+	})
+
+	t.Run("Shared Between Processes", func(t *testing.T) {
+		limiters := newFileLimiters(t, 2, 10, time.Hour)
+
+		r := limiters[0].Reserve(4)
+		if !r.OK() || r.Delay() != 0 {
+			t.Fatalf("Expected an immediately usable reservation, got delay %v", r.Delay())
+		}
+
+		if tokens := limiters[1].GetState().Tokens; tokens != 6 {
+			t.Errorf("Expected the other process to see 6 tokens, got %d", tokens)
+		}
+
+		r.Cancel()
+		if tokens := limiters[1].GetState().Tokens; tokens != 10 {
+			t.Errorf("Expected the cancelled requests to be shared again, got %d", tokens)
+		}
+	})
+}
+
+func TestClientReserve(t *testing.T) {
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+	})
+
+	reservation, err := c.Reserve(3)
+	if err != nil {
+		t.Fatalf("Reserve failed: %v", err)
+	}
+
+	ctx := transport.WithReservation(context.Background(), reservation)
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+	}
+
+	if tokens := c.GetRateLimiterState().Tokens; tokens != 27 || requests != 3 {
+		t.Errorf("Expected 3 requests to spend only the reservation, got %d tokens after %d requests", tokens, requests)
+	}
+
+	// Once the reservation is spent, requests wait on the limiter again
+	if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+		t.Fatalf("GetShip failed: %v", err)
+	}
+	if tokens := c.GetRateLimiterState().Tokens; tokens != 26 {
+		t.Errorf("Expected the fourth request to take a token, got %d tokens", tokens)
+	}
+
+	if _, err := c.Reserve(31); !errors.Is(err, transport.ErrExceedsCapacity) {
+		t.Errorf("Expected ErrExceedsCapacity, got %v", err)
+	}
+
+	if _, err := c.Reserve(-5); !errors.Is(err, transport.ErrInvalidReservation) {
+		t.Errorf("Expected ErrInvalidReservation, got %v", err)
+	}
+	if tokens := c.GetRateLimiterState().Tokens; tokens != 26 {
+		t.Errorf("Expected a negative reservation to leave the budget alone, got %d tokens", tokens)
+	}
+}
@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/schema"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithResponse(t *testing.T) {
+	ctx := context.Background()
+	reset := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-request-id", "req-123")
+			w.Header().Set("x-ratelimit-type", "IP-based")
+			w.Header().Set("x-ratelimit-limit-burst", "30")
+			w.Header().Set("x-ratelimit-limit-per-second", "2")
+			w.Header().Set("x-ratelimit-remaining", "17")
+			w.Header().Set("x-ratelimit-reset", reset.Format(time.RFC3339))
+			w.Write([]byte(`{"data": [{"symbol": "SHIP-1"}], "meta": {"total": 42, "page": 1, "limit": 10}}`))
+		})
+
+		res, err := client.WithResponse(ctx, func(ctx context.Context) ([]schema.Ship, error) {
+			return c.GetFleet(ctx, nil)
+		})
+		if err != nil {
+			t.Fatalf("GetFleet failed: %v", err)
+		}
+
+		if len(res.Data) != 1 || res.Data[0].Symbol != "SHIP-1" {
+			t.Errorf("Unexpected data: %+v", res.Data)
+		}
+
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", res.StatusCode)
+		}
+
+		if res.Meta == nil || res.Meta.Total != 42 {
+			t.Errorf("Expected meta total 42, got %+v", res.Meta)
+		}
+
+		if res.RequestID != "req-123" {
+			t.Errorf("Expected request ID req-123, got %q", res.RequestID)
+		}
+
+		if res.RateLimit.Remaining != 17 || res.RateLimit.LimitBurst != 30 || res.RateLimit.LimitPerSecond != 2 {
+			t.Errorf("Unexpected rate limit info: %+v", res.RateLimit)
+		}
+
+		if !res.RateLimit.Reset.Equal(reset) {
+			t.Errorf("Expected reset %v, got %v", reset, res.RateLimit.Reset)
+		}
+
+		if res.Latency <= 0 {
+			t.Errorf("Expected positive latency, got %v", res.Latency)
+		}
+	})
+
+	t.Run("Error Response", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("x-request-id", "req-404")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"message": "Ship not found", "code": 404}}`))
+		})
+
+		res, err := client.WithResponse(ctx, func(ctx context.Context) (*schema.Ship, error) {
+			return c.GetShip(ctx, "SHIP-404")
+		})
+		if !transport.IsAPIError(err) {
+			t.Fatalf("Expected API error, got: %v", err)
+		}
+
+		if res.StatusCode != http.StatusNotFound || res.RequestID != "req-404" {
+			t.Errorf("Expected 404 response info, got status %d, request ID %q", res.StatusCode, res.RequestID)
+		}
+
+		if res.Meta != nil {
+			t.Errorf("Expected no meta, got %+v", res.Meta)
+		}
+	})
+
+	t.Run("No Content", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		res, err := client.WithResponse(ctx, func(ctx context.Context) (*schema.Cooldown, error) {
+			return c.GetShipCooldown(ctx, "SHIP-1")
+		})
+		if err != nil {
+			t.Fatalf("GetShipCooldown failed: %v", err)
+		}
+
+		if res.Data != nil || res.StatusCode != http.StatusNoContent {
+			t.Errorf("Expected nil cooldown with status 204, got %+v with status %d", res.Data, res.StatusCode)
+		}
+	})
+}
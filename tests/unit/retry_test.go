@@ -0,0 +1,232 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client with a fast retry policy, recording retry events
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, backoff time.Duration) (*client.SpaceTradersClient, *[]transport.RetryEvent) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var events []transport.RetryEvent
+	c, err := client.New(&client.Config{
+		BaseURL: server.URL,
+		Timeout: 5 * time.Second,
+		Token:   "test-token",
+		RetryPolicy: &transport.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: backoff,
+			Multiplier:     2,
+			OnRetry: func(event transport.RetryEvent) {
+				events = append(events, event)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c, &events
+}
+
+// failingHandler fails the first failures requests with status, then serves body
+func failingHandler(failures int32, status int, requests *int32, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error": {"message": "unavailable", "code": 0}}`))
+			return
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Retries Idempotent Request", func(t *testing.T) {
+		var requests int32
+		c, events := newRetryTestClient(t, failingHandler(2, http.StatusServiceUnavailable, &requests, `{"data": {"symbol": "SHIP-1"}}`), time.Millisecond)
+
+		ship, err := c.GetShip(ctx, "SHIP-1")
+		if err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if ship.Symbol != "SHIP-1" || requests != 3 {
+			t.Errorf("Expected success on the third request, got %d requests", requests)
+		}
+
+		if len(*events) != 2 {
+			t.Fatalf("Expected 2 retry events, got %d", len(*events))
+		}
+
+		first := (*events)[0]
+		if first.Attempt != 1 || first.StatusCode != http.StatusServiceUnavailable || first.Request.Path != "/my/ships/SHIP-1" {
+			t.Errorf("Unexpected first retry event: %+v", first)
+		}
+
+		if (*events)[1].Delay != 2*first.Delay {
+			t.Errorf("Expected exponential backoff, got %v then %v", first.Delay, (*events)[1].Delay)
+		}
+	})
+
+	t.Run("Gives Up After Max Attempts", func(t *testing.T) {
+		var requests int32
+		c, _ := newRetryTestClient(t, failingHandler(10, http.StatusBadGateway, &requests, ""), time.Millisecond)
+
+		_, err := c.GetShip(ctx, "SHIP-1")
+		if !transport.IsAPIError(err) {
+			t.Fatalf("Expected API error, got: %v", err)
+		}
+
+		if requests != 3 {
+			t.Errorf("Expected 3 attempts, got %d", requests)
+		}
+	})
+
+	t.Run("Does Not Retry Non-Idempotent Request", func(t *testing.T) {
+		var requests int32
+		c, events := newRetryTestClient(t, failingHandler(1, http.StatusServiceUnavailable, &requests, `{"data": {}}`), time.Millisecond)
+
+		if _, err := c.PurchaseShip(ctx, "SHIP_PROBE", "X1-TEST-A1"); !transport.IsAPIError(err) {
+			t.Fatalf("Expected API error, got: %v", err)
+		}
+
+		if requests != 1 || len(*events) != 0 {
+			t.Errorf("Expected a single attempt, got %d requests and %d retries", requests, len(*events))
+		}
+	})
+
+	t.Run("Retries Request Marked Idempotent", func(t *testing.T) {
+		var requests int32
+		c, _ := newRetryTestClient(t, failingHandler(1, http.StatusGatewayTimeout, &requests, `{"data": {"nav": {"flightMode": "DRIFT"}}}`), time.Millisecond)
+
+		result, err := c.SetFlightMode(ctx, "SHIP-1", "DRIFT")
+		if err != nil {
+			t.Fatalf("SetFlightMode failed: %v", err)
+		}
+
+		if result.Nav.FlightMode != "DRIFT" || requests != 2 {
+			t.Errorf("Expected success on retry, got %d requests", requests)
+		}
+	})
+
+	t.Run("Does Not Retry Client Errors", func(t *testing.T) {
+		var requests int32
+		c, _ := newRetryTestClient(t, failingHandler(1, http.StatusNotFound, &requests, ""), time.Millisecond)
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); !transport.IsAPIError(err) {
+			t.Fatalf("Expected API error, got: %v", err)
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected a single attempt, got %d", requests)
+		}
+	})
+
+	t.Run("Rate Limited Request Honors Reset", func(t *testing.T) {
+		var requests int32
+		c, events := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.Header().Set("x-ratelimit-reset", time.Now().Add(50*time.Millisecond).Format(time.RFC3339Nano))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"ship": {"symbol": "SHIP-2"}}}`))
+		}, time.Hour)
+
+		// 429s are retried even for POST, since the server did not process the request
+		result, err := c.PurchaseShip(ctx, "SHIP_PROBE", "X1-TEST-A1")
+		if err != nil {
+			t.Fatalf("PurchaseShip failed: %v", err)
+		}
+
+		if result.Ship.Symbol != "SHIP-2" || len(*events) != 1 {
+			t.Fatalf("Expected one retry before success, got %d", len(*events))
+		}
+
+		event := (*events)[0]
+		if !transport.IsRateLimitError(event.Err) || event.Delay <= 0 || event.Delay > 50*time.Millisecond {
+			t.Errorf("Expected rate limit retry delayed until the reset, got %+v", event)
+		}
+	})
+
+	t.Run("Retries Connection Failures", func(t *testing.T) {
+		var requests int32
+		c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+		}, time.Millisecond)
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if requests != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests)
+		}
+	})
+
+	t.Run("Does Not Retry Permanent Transport Errors", func(t *testing.T) {
+		retries := 0
+		c, err := client.New(&client.Config{
+			BaseURL: "badscheme://host",
+			Token:   "test-token",
+			RetryPolicy: &transport.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				OnRetry:        func(transport.RetryEvent) { retries++ },
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err == nil {
+			t.Fatal("Expected an unsupported protocol scheme error")
+		}
+
+		if retries != 0 {
+			t.Errorf("Expected no retries, got %d", retries)
+		}
+	})
+
+	t.Run("Context Cancelled During Backoff", func(t *testing.T) {
+		var requests int32
+		c, _ := newRetryTestClient(t, failingHandler(10, http.StatusServiceUnavailable, &requests, ""), time.Hour)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		if _, err := c.GetShip(timeoutCtx, "SHIP-1"); err == nil {
+			t.Fatal("Expected an error")
+		}
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected cancellation to interrupt the backoff, took %v", elapsed)
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected a single attempt, got %d", requests)
+		}
+	})
+}
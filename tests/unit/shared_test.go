@@ -0,0 +1,206 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/client"
+	"github.com/JoeEdwardsCode/spacetraders-client/pkg/transport"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newFileLimiters opens n limiters sharing one state file, as separate processes would
+func newFileLimiters(t *testing.T, n, capacity int, refillRate time.Duration) []*ratelimit.FileLimiter {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	limiters := make([]*ratelimit.FileLimiter, n)
+	for i := range limiters {
+		limiter, err := ratelimit.NewCustomFileLimiter(path, capacity, refillRate)
+		if err != nil {
+			t.Fatalf("Failed to create file limiter: %v", err)
+		}
+		t.Cleanup(func() { limiter.Close() })
+		limiters[i] = limiter
+	}
+
+	return limiters
+}
+
+func TestFileLimiter(t *testing.T) {
+	t.Run("Shares Budget", func(t *testing.T) {
+		limiters := newFileLimiters(t, 2, 10, time.Hour)
+
+		allowed := 0
+		for i := 0; i < 20; i++ {
+			if limiters[i%2].TryAllow() {
+				allowed++
+			}
+		}
+
+		if allowed != 10 {
+			t.Errorf("Expected the limiters to share 10 tokens, allowed %d", allowed)
+		}
+
+		if tokens := limiters[0].GetState().Tokens; tokens != 0 {
+			t.Errorf("Expected an empty shared bucket, got %d tokens", tokens)
+		}
+	})
+
+	t.Run("Shares Pause And Remaining", func(t *testing.T) {
+		limiters := newFileLimiters(t, 2, 10, time.Hour)
+
+		limiters[0].SetRemaining(4)
+		if tokens := limiters[1].GetState().Tokens; tokens != 4 {
+			t.Errorf("Expected the other limiter to see 4 tokens, got %d", tokens)
+		}
+
+		limiters[0].PauseUntil(time.Now().Add(time.Hour))
+		if limiters[1].TryAllow() {
+			t.Error("Expected the pause to apply to the other limiter")
+		}
+
+		if state := limiters[1].GetState(); state.PausedUntil.IsZero() {
+			t.Errorf("Expected the shared state to report the pause, got %+v", state)
+		}
+
+		limiters[1].Reset()
+		if !limiters[0].TryAllow() {
+			t.Error("Expected a reset to clear the pause for every limiter")
+		}
+	})
+
+	t.Run("Wait For Refill By Other Process", func(t *testing.T) {
+		limiters := newFileLimiters(t, 2, 1, 20*time.Millisecond)
+		limiters[0].TryAllow()
+
+		start := time.Now()
+		if err := limiters[1].Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("Expected Wait to block until the shared refill, took %v", elapsed)
+		}
+	})
+
+	t.Run("Shares Dual Window Budget", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratelimit.json")
+		limiters := make([]*ratelimit.FileLimiter, 2)
+		for i := range limiters {
+			limiter, err := ratelimit.NewCustomDualWindowFileLimiter(path, 2, 3, time.Hour)
+			if err != nil {
+				t.Fatalf("Failed to create file limiter: %v", err)
+			}
+			defer limiter.Close()
+			limiters[i] = limiter
+		}
+
+		allowed := 0
+		for i := 0; i < 10; i++ {
+			if limiters[i%2].TryAllow() {
+				allowed++
+			}
+		}
+
+		if allowed != 5 {
+			t.Errorf("Expected the limiters to share 2 static and 3 burst requests, allowed %d", allowed)
+		}
+
+		if state := limiters[1].GetState(); state.Capacity != 5 || state.Tokens != 0 {
+			t.Errorf("Expected an exhausted shared budget of 5, got %+v", state)
+		}
+
+		// The static window refills while the burst pool stays spent
+		time.Sleep(600 * time.Millisecond)
+		if !limiters[0].TryAllow() || limiters[1].TryAllow() {
+			t.Error("Expected exactly one request refilled by the static window")
+		}
+	})
+
+	t.Run("Corrupt State File Starts Full", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratelimit.json")
+		if err := os.WriteFile(path, []byte(`{"tokens": 3, "last_re`), 0o600); err != nil {
+			t.Fatalf("Failed to write state file: %v", err)
+		}
+
+		limiter, err := ratelimit.NewCustomFileLimiter(path, 10, time.Hour)
+		if err != nil {
+			t.Fatalf("Failed to create file limiter: %v", err)
+		}
+		defer limiter.Close()
+
+		if state := limiter.GetState(); state.Tokens != 10 || limiter.Err() != nil {
+			t.Errorf("Expected a full bucket without errors, got %+v and %v", state, limiter.Err())
+		}
+	})
+}
+
+func TestClientRateLimiterBackends(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"symbol": "SHIP-1"}}`))
+	}))
+	defer server.Close()
+
+	t.Run("Custom Limiter", func(t *testing.T) {
+		c, err := client.New(&client.Config{
+			BaseURL:     server.URL,
+			Token:       "test-token",
+			RateLimiter: transport.NewDualWindowLimiter(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer c.Close()
+
+		if _, err := c.GetShip(ctx, "SHIP-1"); err != nil {
+			t.Fatalf("GetShip failed: %v", err)
+		}
+
+		if state := c.GetRateLimiterState(); state.Capacity != 32 || state.Tokens != 31 {
+			t.Errorf("Expected the dual window limiter to be used, got %+v", state)
+		}
+	})
+
+	t.Run("Shared State File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ratelimit.json")
+		clients := make([]*client.SpaceTradersClient, 2)
+		for i := range clients {
+			c, err := client.New(&client.Config{
+				BaseURL:            server.URL,
+				Token:              "test-token",
+				RateLimitStateFile: path,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			defer c.Close()
+			clients[i] = c
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, err := clients[1].GetShip(ctx, "SHIP-1"); err != nil {
+				t.Fatalf("GetShip failed: %v", err)
+			}
+		}
+
+		if tokens := clients[0].GetRateLimiterState().Tokens; tokens > 27 {
+			t.Errorf("Expected the first client to see the second client's requests, got %d tokens", tokens)
+		}
+	})
+
+	t.Run("Unusable State File", func(t *testing.T) {
+		_, err := client.New(&client.Config{
+			BaseURL:            server.URL,
+			RateLimitStateFile: filepath.Join(t.TempDir(), "missing", "ratelimit.json"),
+		})
+		if err == nil {
+			t.Error("Expected an error for a state file in a missing directory")
+		}
+	})
+}
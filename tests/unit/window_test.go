@@ -0,0 +1,235 @@
+package unit
+
+import (
+	"context"
+	"github.com/JoeEdwardsCode/spacetraders-client/internal/ratelimit"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced ratelimit.Clock for deterministic tests
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiters chan struct{} // Receives a value whenever a timer is created
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		waiters: make(chan struct{}, 100),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+	} else {
+		c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	}
+	c.waiters <- struct{}{}
+
+	return ch
+}
+
+// Advance moves the clock forward, firing any timers that have come due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// allowN returns how many of n immediate requests the limiter allows
+func allowN(limiter *ratelimit.DualWindowLimiter, n int) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		if limiter.TryAllow() {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestDualWindowLimiter(t *testing.T) {
+	t.Run("Static Then Burst", func(t *testing.T) {
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, newFakeClock())
+
+		if allowed := allowN(limiter, 40); allowed != 32 {
+			t.Errorf("Expected 2 static plus 30 burst requests, got %d", allowed)
+		}
+
+		static, burst := limiter.GetWindowStates()
+		if static.Tokens != 0 || burst.Tokens != 0 {
+			t.Errorf("Expected both windows to be empty, got static %d and burst %d", static.Tokens, burst.Tokens)
+		}
+	})
+
+	t.Run("Static Limit Preserves Burst", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, clock)
+
+		// Two requests every second stay within the static limit
+		for i := 0; i < 10; i++ {
+			if allowed := allowN(limiter, 2); allowed != 2 {
+				t.Fatalf("Second %d: expected 2 requests, got %d", i, allowed)
+			}
+			clock.Advance(time.Second)
+		}
+
+		if _, burst := limiter.GetWindowStates(); burst.Tokens != 30 {
+			t.Errorf("Expected the burst pool to be untouched, got %d", burst.Tokens)
+		}
+	})
+
+	t.Run("Fractional Refill", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		// Partial refills accumulate across checks rather than being truncated
+		for i := 0; i < 4; i++ {
+			clock.Advance(125 * time.Millisecond)
+			if i < 3 && limiter.TryAllow() {
+				t.Fatalf("Expected no request after %v", time.Duration(i+1)*125*time.Millisecond)
+			}
+		}
+
+		if !limiter.TryAllow() {
+			t.Error("Expected a request after 500ms of accumulated refill")
+		}
+
+		clock.Advance(500*time.Millisecond - time.Nanosecond)
+		if limiter.TryAllow() {
+			t.Error("Expected no request 1ns short of a full refill")
+		}
+
+		clock.Advance(time.Nanosecond)
+		if !limiter.TryAllow() {
+			t.Error("Expected a request exactly at the refill")
+		}
+	})
+
+	t.Run("Burst Window Refill", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, clock)
+		allowN(limiter, 32)
+
+		// The static window refills fully within a second, the burst pool at one request per 2s
+		clock.Advance(2 * time.Second)
+		if allowed := allowN(limiter, 10); allowed != 3 {
+			t.Errorf("Expected 2 static plus 1 burst request after 2s, got %d", allowed)
+		}
+
+		clock.Advance(60 * time.Second)
+		if allowed := allowN(limiter, 40); allowed != 32 {
+			t.Errorf("Expected both windows to be full after the burst window, got %d", allowed)
+		}
+	})
+
+	t.Run("Wait Blocks Until Refill", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- limiter.Wait(context.Background())
+		}()
+
+		<-clock.waiters
+		select {
+		case <-done:
+			t.Fatal("Wait returned before the refill")
+		default:
+		}
+
+		clock.Advance(500 * time.Millisecond)
+		if err := <-done; err != nil {
+			t.Errorf("Wait failed: %v", err)
+		}
+	})
+
+	t.Run("Wait Honors Pause", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, clock)
+		limiter.PauseUntil(clock.Now().Add(3 * time.Second))
+
+		done := make(chan error, 1)
+		go func() {
+			done <- limiter.Wait(context.Background())
+		}()
+
+		<-clock.waiters
+		clock.Advance(2 * time.Second)
+		select {
+		case <-done:
+			t.Fatal("Wait returned during the pause")
+		default:
+		}
+
+		clock.Advance(time.Second)
+		if err := <-done; err != nil {
+			t.Errorf("Wait failed: %v", err)
+		}
+	})
+
+	t.Run("Wait Cancelled", func(t *testing.T) {
+		clock := newFakeClock()
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 0, 60*time.Second, clock)
+		allowN(limiter, 2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- limiter.Wait(ctx)
+		}()
+
+		<-clock.waiters
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Set Remaining Drains Burst First", func(t *testing.T) {
+		limiter := ratelimit.NewCustomDualWindowLimiter(2, 30, 60*time.Second, newFakeClock())
+		limiter.SetRemaining(5)
+
+		static, burst := limiter.GetWindowStates()
+		if static.Tokens != 2 || burst.Tokens != 3 {
+			t.Errorf("Expected 2 static and 3 burst requests, got %d and %d", static.Tokens, burst.Tokens)
+		}
+
+		if state := limiter.GetState(); state.Tokens != 5 || state.Capacity != 32 {
+			t.Errorf("Expected 5 of 32 requests remaining, got %d of %d", state.Tokens, state.Capacity)
+		}
+	})
+}